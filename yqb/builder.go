@@ -0,0 +1,41 @@
+package yqb
+
+// Builders are immutable values: every method copies the builder data,
+// changes the copy and returns a new builder, so a builder can be reused
+// and extended from several goroutines. The copy is shallow, slices of
+// the data are never changed in place, see appendCopy.
+
+// statementOptions are the options of the statement set by StatementBuilderType
+// for any child builders.
+type statementOptions struct {
+	PlaceholderFormat PlaceholderFormat
+	RunWith           BaseRunner
+	ListParams        bool
+	LimitParams       bool
+	IdentMode         IdentMode
+	OmitDeclare       bool
+	SafeMode          bool
+	Limits            Limits
+}
+
+// dataOf returns the builder data, the data of zero builders is empty.
+func dataOf[T any](d *T) *T {
+	if d == nil {
+		return new(T)
+	}
+	return d
+}
+
+// clone returns a copy of the builder data to change.
+func clone[T any](d *T) *T {
+	c := new(T)
+	if d != nil {
+		*c = *d
+	}
+	return c
+}
+
+// appendCopy appends items to a copy of s, s may be shared by other builders.
+func appendCopy[E any](s []E, items ...E) []E {
+	return append(s[:len(s):len(s)], items...)
+}
@@ -0,0 +1,99 @@
+package yqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderCopyOnWrite(t *testing.T) {
+	base := Select("id").From("t").Where(Eq{"a": 1})
+	b1 := base.Where(Eq{"b": 2}).Columns("x")
+	b2 := base.Where(Eq{"c": 3}).Columns("y")
+
+	sql, _, err := base.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE a = $p1", sql)
+
+	sql, _, err = b1.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, x FROM t WHERE a = $p1 AND b = $p2", sql)
+
+	sql, _, err = b2.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id, y FROM t WHERE a = $p1 AND c = $p2", sql)
+
+	sb := StatementBuilder.Where(Eq{"tenant": 1})
+	sql, _, err = sb.Where(Eq{"a": 2}).Delete("t").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE tenant = $p1 AND a = $p2", sql)
+
+	sql, _, err = sb.Update("t").Set("a", 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET a = $p1 WHERE tenant = $p2", sql)
+
+	sql, _, err = sb.Insert("t").Values(1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO t VALUES ($p1)", sql)
+}
+
+func TestZeroBuilder(t *testing.T) {
+	sql, _, err := SelectBuilder{}.PlaceholderFormat(Question).Columns("id").From("t").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t", sql)
+}
+
+func BenchmarkSelectToYdbSql(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Select("id", "name").
+			From("users").
+			Where(Eq{"status": 1}).
+			Where("created_at > ?", 2).
+			OrderBy("id").
+			Limit(10).
+			ToYdbSql()
+	}
+}
+
+func BenchmarkInsertToYdbSql(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Insert("users").
+			Columns("id", "name").
+			Values(1, "a").
+			Values(2, "b").
+			ToYdbSql()
+	}
+}
+
+func BenchmarkUpdateToYdbSql(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Update("users").
+			Set("name", "a").
+			Set("status", 2).
+			Where(Eq{"id": 1}).
+			ToYdbSql()
+	}
+}
+
+func BenchmarkDeleteToYdbSql(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Delete("users").
+			Where(Eq{"id": 1}).
+			ToYdbSql()
+	}
+}
+
+func BenchmarkCaseToSql(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Case("status").
+			When("1", "'a'").
+			When("2", "'b'").
+			Else("'c'").
+			ToSql()
+	}
+}
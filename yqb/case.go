@@ -3,14 +3,8 @@ package yqb
 import (
 	"bytes"
 	"errors"
-
-	"github.com/lann/builder"
 )
 
-func init() {
-	builder.Register(CaseBuilder{}, caseData{})
-}
-
 // sqlizerBuffer is a helper that allows to write many Sqlizers one by one
 // without constant checks for errors that may come from Sqlizer
 type sqlizerBuffer struct {
@@ -106,27 +100,39 @@ func (d *caseData) toSqlRaw() (sqlStr string, args []any, err error) {
 }
 
 // CaseBuilder builds SQL CASE construct which could be used as parts of queries.
-type CaseBuilder builder.Builder
+type CaseBuilder struct {
+	d *caseData
+}
+
+func (b CaseBuilder) data() *caseData {
+	return dataOf(b.d)
+}
 
 // ToSql builds the query into a SQL string and bound args.
 func (b CaseBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(caseData)
+	data := b.data()
 	return data.ToSql()
 }
 
 // what sets optional value for CASE construct "CASE [value] ..."
 func (b CaseBuilder) what(expr any) CaseBuilder {
-	return builder.Set(b, "What", newPart(expr)).(CaseBuilder)
+	d := clone(b.d)
+	d.What = newPart(expr)
+	return CaseBuilder{d}
 }
 
 // When adds "WHEN ... THEN ..." part to CASE construct
 func (b CaseBuilder) When(when any, then any) CaseBuilder {
 	// TODO: performance hint: replace slice of WhenPart with just slice of parts
 	// where even indices of the slice belong to "when"s and odd indices belong to "then"s
-	return builder.Append(b, "WhenParts", newWhenPart(when, then)).(CaseBuilder)
+	d := clone(b.d)
+	d.WhenParts = appendCopy(d.WhenParts, newWhenPart(when, then))
+	return CaseBuilder{d}
 }
 
 // What sets optional "ELSE ..." part for CASE construct
 func (b CaseBuilder) Else(expr any) CaseBuilder {
-	return builder.Set(b, "Else", newPart(expr)).(CaseBuilder)
+	d := clone(b.d)
+	d.Else = newPart(expr)
+	return CaseBuilder{d}
 }
@@ -9,20 +9,19 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
 type createStmt struct {
-	PlaceholderFormat PlaceholderFormat
-	RunWith           BaseRunner
-	Prefixes          []Sqlizer
-	StatementKeyword  string
-	Table             string
-	Columns           []string
-	Types             []string
-	PrimaryKey        []Sqlizer
-	Suffixes          []Sqlizer
+	statementOptions
+
+	Prefixes         []Sqlizer
+	StatementKeyword string
+	Table            string
+	Columns          []string
+	Types            []string
+	PrimaryKey       []Sqlizer
+	Suffixes         []Sqlizer
 }
 
 func (d *createStmt) Exec() (sql.Result, error) {
@@ -84,15 +83,29 @@ func (d *createStmt) toSqlRaw() (sqlStr string, args []any, err error) {
 		sql.WriteString(d.StatementKeyword)
 		sql.WriteString(" ")
 	}
+	tableName, err := d.IdentMode.apply(d.Table)
+	if err != nil {
+		return
+	}
+
 	sql.WriteString("TABLE ")
-	sql.WriteString(d.Table)
+	sql.WriteString(tableName)
 	sql.WriteString(" ")
 	sql.WriteString("( ")
 	err = d.appendValuesToSQL(sql)
+	if err != nil {
+		return
+	}
 	if len(d.PrimaryKey) > 0 {
+		var primaryKey []Sqlizer
+		primaryKey, err = d.IdentMode.applyList(d.PrimaryKey)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(", PRIMARY KEY ")
 		sql.WriteString("(")
-		args, err = appendToSql(d.PrimaryKey, sql, ", ", args)
+		args, err = appendToSql(primaryKey, sql, ", ", args)
 		sql.WriteString(")")
 		if err != nil {
 			return
@@ -126,8 +139,13 @@ func (d *createStmt) appendValuesToSQL(w io.Writer) error {
 	if len(d.Types) != len(d.Columns) {
 		return errors.New("types size are not equal to columns for create statements are not set")
 	}
-	valueStrings := make([]string, len(d.Columns))
-	for i, col := range d.Columns {
+	columns, err := d.IdentMode.applyAll(d.Columns)
+	if err != nil {
+		return err
+	}
+
+	valueStrings := make([]string, len(columns))
+	for i, col := range columns {
 		valueStrings[i] = fmt.Sprintf("%s %s", col, d.Types[i])
 		valueStrings[i] = strings.TrimSuffix(valueStrings[i], " ")
 	}
@@ -139,10 +157,12 @@ func (d *createStmt) appendValuesToSQL(w io.Writer) error {
 // Builder
 
 // CreateBuilder builds SQL CREATE statements.
-type CreateBuilder builder.Builder
+type CreateBuilder struct {
+	d *createStmt
+}
 
-func init() {
-	builder.Register(CreateBuilder{}, createStmt{})
+func (b CreateBuilder) data() *createStmt {
+	return dataOf(b.d)
 }
 
 // Format methods
@@ -150,25 +170,57 @@ func init() {
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b CreateBuilder) PlaceholderFormat(f PlaceholderFormat) CreateBuilder {
-	return builder.Set(b, "PlaceholderFormat", f).(CreateBuilder)
+	d := clone(b.d)
+	d.PlaceholderFormat = f
+	return CreateBuilder{d}
+}
+
+// IdentMode sets IdentMode (e.g. IdentQuote or IdentValidate) for table and
+// column names of the query.
+//
+// See StatementBuilderType.IdentMode.
+func (b CreateBuilder) IdentMode(mode IdentMode) CreateBuilder {
+	d := clone(b.d)
+	d.IdentMode = mode
+	return CreateBuilder{d}
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b CreateBuilder) OmitDeclare(omit bool) CreateBuilder {
+	d := clone(b.d)
+	d.OmitDeclare = omit
+	return CreateBuilder{d}
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b CreateBuilder) Limits(limits Limits) CreateBuilder {
+	d := clone(b.d)
+	d.Limits = limits
+	return CreateBuilder{d}
 }
 
 // Runner methods
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b CreateBuilder) RunWith(runner BaseRunner) CreateBuilder {
-	return setRunWith(b, runner).(CreateBuilder)
+	d := clone(b.d)
+	d.RunWith = wrapRunner(runner)
+	return CreateBuilder{d}
 }
 
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b CreateBuilder) Exec() (sql.Result, error) {
-	data := builder.GetStruct(b).(createStmt)
+	data := b.data()
 	return data.Exec()
 }
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b CreateBuilder) Query() (*sql.Rows, error) {
-	data := builder.GetStruct(b).(createStmt)
+	data := b.data()
 	return data.Query()
 }
 
@@ -176,10 +228,15 @@ func (b CreateBuilder) Query() (*sql.Rows, error) {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b CreateBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(createStmt)
+	data := b.data()
 	return data.ToSql()
 }
 
+func (b CreateBuilder) toSqlRaw() (string, []any, error) {
+	data := b.data()
+	return data.toSqlRaw()
+}
+
 // ToYdbSql builds the query into a SQL string and bound args.
 func (b CreateBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 	sqlStr, args, err := b.ToSql()
@@ -187,7 +244,7 @@ func (b CreateBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
 	}
 
-	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args)
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, b.data().OmitDeclare)
 	if err != nil {
 		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
 	}
@@ -197,6 +254,10 @@ func (b CreateBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
 	}
 
+	if err = checkLimits(b.data().Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
 	return ydbSqlStr, ydbArgs, err
 }
 
@@ -207,32 +268,52 @@ func (b CreateBuilder) Prefix(sql string, args ...any) CreateBuilder {
 
 // PrefixExpr adds an expression to the very beginning of the query
 func (b CreateBuilder) PrefixExpr(expr Sqlizer) CreateBuilder {
-	return builder.Append(b, "Prefixes", expr).(CreateBuilder)
+	d := clone(b.d)
+	d.Prefixes = appendCopy(d.Prefixes, expr)
+	return CreateBuilder{d}
 }
 
 // Table sets the TABLE clause of the query.
 func (b CreateBuilder) Table(table string) CreateBuilder {
-	return builder.Set(b, "Table", table).(CreateBuilder)
+	d := clone(b.d)
+	d.Table = table
+	return CreateBuilder{d}
 }
 
 // Columns adds create columns to the query.
 func (b CreateBuilder) Columns(columns ...string) CreateBuilder {
-	return builder.Extend(b, "Columns", columns).(CreateBuilder)
+	d := clone(b.d)
+	d.Columns = appendCopy(d.Columns, columns...)
+	return CreateBuilder{d}
 }
 
 // Types adds create types to the query.
 func (b CreateBuilder) Types(types ...string) CreateBuilder {
-	return builder.Extend(b, "Types", types).(CreateBuilder)
+	d := clone(b.d)
+	d.Types = appendCopy(d.Types, types...)
+	return CreateBuilder{d}
 }
 
 // PrimaryKey adds an primary key to the query
+//
+// Without args the sql is a comma separated list of columns, e.g. "user_id, id",
+// the IdentMode of the builder is applied to them.
 func (b CreateBuilder) PrimaryKey(sql string, args ...any) CreateBuilder {
+	if len(args) == 0 {
+		columns := strings.Split(sql, ",")
+		for i, column := range columns {
+			columns[i] = strings.TrimSpace(column)
+		}
+		return b.PrimaryKeyExpr(identList(columns))
+	}
 	return b.PrimaryKeyExpr(Expr(sql, args...))
 }
 
 // PrimaryKeyExpr adds an expression to the query
 func (b CreateBuilder) PrimaryKeyExpr(expr Sqlizer) CreateBuilder {
-	return builder.Append(b, "PrimaryKey", expr).(CreateBuilder)
+	d := clone(b.d)
+	d.PrimaryKey = appendCopy(d.PrimaryKey, expr)
+	return CreateBuilder{d}
 }
 
 // Suffix adds an expression to the end of the query
@@ -242,7 +323,9 @@ func (b CreateBuilder) Suffix(sql string, args ...any) CreateBuilder {
 
 // SuffixExpr adds an expression to the end of the query
 func (b CreateBuilder) SuffixExpr(expr Sqlizer) CreateBuilder {
-	return builder.Append(b, "Suffixes", expr).(CreateBuilder)
+	d := clone(b.d)
+	d.Suffixes = appendCopy(d.Suffixes, expr)
+	return CreateBuilder{d}
 }
 
 // SetMap set columns and values for insert builder from a map of column name and value
@@ -251,7 +334,7 @@ func (b CreateBuilder) SetMap(clauses map[string]string) CreateBuilder {
 	// Keep the columns in a consistent order by sorting the column key string.
 	cols := make([]string, 0, len(clauses))
 	for col := range clauses {
-		cols = append(cols, col+" "+clauses[col])
+		cols = append(cols, col)
 	}
 	sort.Strings(cols)
 
@@ -260,8 +343,8 @@ func (b CreateBuilder) SetMap(clauses map[string]string) CreateBuilder {
 		vals = append(vals, clauses[col])
 	}
 
-	b = builder.Set(b, "Columns", cols).(CreateBuilder)
-	b = builder.Set(b, "Types", vals).(CreateBuilder)
-
-	return b
+	d := clone(b.d)
+	d.Columns = cols
+	d.Types = vals
+	return CreateBuilder{d}
 }
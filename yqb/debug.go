@@ -0,0 +1,256 @@
+package yqb
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// DebugYql calls ToSql on s and returns the query with every parameter inlined
+// as a typed YQL literal, e.g. Uint32("5"), Timestamp("2023-01-02T03:04:05.000000Z"),
+// AsList(Int64("1"), Int64("2")) or Nothing(Optional<Utf8>).
+// The result doesn't need DECLARE and can be run as is, e.g. in the YDB UI.
+//
+// It is meant for debugging, errors are returned inside the string.
+// Ex:
+//
+//	DebugYql(Select("id").From("t").Where(Eq{"id": uint32(5)}))
+//	// SELECT id FROM t WHERE id = Uint32("5")
+func DebugYql(s Sqlizer) string {
+	yql, err := inlineYql(s, yqlLiteral)
+	if err != nil {
+		return fmt.Sprintf("[DebugYql error: %s]", err)
+	}
+	return yql
+}
+
+// InlineYql calls ToSql on s and returns the query with every parameter inlined
+// for scheme queries that can't have parameters. Strings are inlined as plain
+// literals the scheme statements expect, e.g. Interval("P7D") in TTL, other
+// values as typed YQL literals like DebugYql.
+func InlineYql(s Sqlizer) (string, error) {
+	return inlineYql(s, schemeLiteral)
+}
+
+func inlineYql(s Sqlizer, literal func(v types.Value) (string, error)) (string, error) {
+	sql, args, err := nestedToSql(s)
+	if err != nil {
+		return "", err
+	}
+
+	args, err = castArgsToYdb(args)
+	if err != nil {
+		return "", err
+	}
+
+	placeholders := 0
+	yql, err := replacePlaceholders(sql, func(i int) (string, error) {
+		if i > len(args) {
+			return "", fmt.Errorf("too many placeholders in %#v for %d args", sql, len(args))
+		}
+		placeholders = i
+		return literal(args[i-1].(types.Value))
+	})
+	if err != nil {
+		return "", err
+	}
+	if placeholders < len(args) {
+		return "", fmt.Errorf("not enough placeholders in %#v for %d args", sql, len(args))
+	}
+
+	return yql, nil
+}
+
+// yqlLiteral renders the value as a typed YQL literal.
+func yqlLiteral(v types.Value) (string, error) {
+	t := v.Type()
+	typeYql := t.Yql()
+
+	if isOptional, innerType := types.IsOptional(t); isOptional {
+		if strings.HasPrefix(v.Yql(), "Nothing(") {
+			return fmt.Sprintf("Nothing(%s)", typeYql), nil
+		}
+		if !primitiveLiteralTypes[innerType.Yql()] {
+			return v.Yql(), nil
+		}
+		// primitive optional values are cast the same way as their inner values
+		inner, err := primitiveLiteral(innerType.Yql(), v)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Just(%s)", inner), nil
+	}
+
+	switch {
+	case strings.HasPrefix(typeYql, "List<"):
+		items, err := types.ListItems(v)
+		if err != nil {
+			return "", err
+		}
+		if len(items) == 0 {
+			return fmt.Sprintf("ListCreate(%s)", strings.TrimSuffix(strings.TrimPrefix(typeYql, "List<"), ">")), nil
+		}
+		return valuesLiteral("AsList", items)
+	case strings.HasPrefix(typeYql, "Tuple<"):
+		items, err := types.TupleItems(v)
+		if err != nil {
+			return "", err
+		}
+		return valuesLiteral("AsTuple", items)
+	case strings.HasPrefix(typeYql, "Struct<"):
+		fields, err := types.StructFields(v)
+		if err != nil {
+			return "", err
+		}
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		literals := make([]string, 0, len(names))
+		for _, name := range names {
+			literal, err := yqlLiteral(fields[name])
+			if err != nil {
+				return "", err
+			}
+			literals = append(literals, fmt.Sprintf("%s AS %s", literal, quoteIdent(name)))
+		}
+		return fmt.Sprintf("AsStruct(%s)", strings.Join(literals, ", ")), nil
+	case strings.HasPrefix(typeYql, "Dict<"):
+		values, err := types.DictValues(v)
+		if err != nil {
+			return "", err
+		}
+		if len(values) == 0 {
+			return v.Yql(), nil
+		}
+		literals := make([]string, 0, len(values))
+		for key, val := range values {
+			literal, err := valuesLiteral("AsTuple", []types.Value{key, val})
+			if err != nil {
+				return "", err
+			}
+			literals = append(literals, literal)
+		}
+		sort.Strings(literals)
+		return fmt.Sprintf("AsDict(%s)", strings.Join(literals, ", ")), nil
+	}
+
+	if primitiveLiteralTypes[typeYql] {
+		return primitiveLiteral(typeYql, v)
+	}
+
+	// Decimal, Uuid, Interval, Void and other values with valid literals in the SDK
+	return v.Yql(), nil
+}
+
+// schemeLiteral renders Utf8 and String values as plain string literals,
+// other values as typed YQL literals.
+func schemeLiteral(v types.Value) (string, error) {
+	typeYql := v.Type().Yql()
+	if typeYql != "Utf8" && typeYql != "String" {
+		return yqlLiteral(v)
+	}
+
+	var s string
+	if err := types.CastTo(v, &s); err != nil {
+		return "", err
+	}
+	return quoteString(s, typeYql == "String"), nil
+}
+
+func valuesLiteral(fn string, values []types.Value) (string, error) {
+	literals := make([]string, 0, len(values))
+	for _, item := range values {
+		literal, err := yqlLiteral(item)
+		if err != nil {
+			return "", err
+		}
+		literals = append(literals, literal)
+	}
+	return fmt.Sprintf("%s(%s)", fn, strings.Join(literals, ", ")), nil
+}
+
+// primitiveLiteralTypes contains primitive types rendered as Type("value").
+var primitiveLiteralTypes = map[string]bool{
+	"Bool": true, "Float": true, "Double": true, "DyNumber": true,
+	"Int8": true, "Int16": true, "Int32": true, "Int64": true,
+	"Uint8": true, "Uint16": true, "Uint32": true, "Uint64": true,
+	"String": true, "Utf8": true, "Json": true, "JsonDocument": true, "Yson": true,
+	"Date": true, "Datetime": true, "Timestamp": true,
+	"TzDate": true, "TzDatetime": true, "TzTimestamp": true,
+}
+
+// timeLiteralLayouts contains layouts of time types literals.
+var timeLiteralLayouts = map[string]string{
+	"Date":      "2006-01-02",
+	"Datetime":  "2006-01-02T15:04:05Z",
+	"Timestamp": "2006-01-02T15:04:05.000000Z",
+}
+
+func primitiveLiteral(typeYql string, v types.Value) (string, error) {
+	if layout, ok := timeLiteralLayouts[typeYql]; ok {
+		var t time.Time
+		if err := types.CastTo(v, &t); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s)", typeYql, QuoteString(t.UTC().Format(layout))), nil
+	}
+
+	if typeYql == "Bool" {
+		var b bool
+		if err := types.CastTo(v, &b); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%t", b), nil
+	}
+
+	var s string
+	if err := types.CastTo(v, &s); err != nil {
+		return "", err
+	}
+	if typeYql == "String" {
+		// String may contain any bytes, keep the query text valid UTF-8
+		return fmt.Sprintf("%s(%s)", typeYql, quoteString(s, true)), nil
+	}
+	return fmt.Sprintf("%s(%s)", typeYql, QuoteString(s)), nil
+}
+
+// QuoteString quotes s as a YQL string literal.
+// Ex:
+//
+//	QuoteString(`$."a b"`) == `"$.\"a b\""`
+func QuoteString(s string) string {
+	return quoteString(s, false)
+}
+
+func quoteString(s string, escapeNonASCII bool) string {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f || (escapeNonASCII && c >= 0x80) {
+				fmt.Fprintf(buf, `\x%02X`, c)
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
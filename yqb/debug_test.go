@@ -0,0 +1,61 @@
+package yqb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestDebugYql(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 3, 4, 5, 6000, time.UTC)
+	var nilName *string
+
+	b := Select("id").
+		From("t").
+		Where(Eq{"a": uint32(5), "b": int64(-3), "c": "x\"y", "d": []byte("\xff")}).
+		Where("e = ? AND f = ? AND g = ?", ts, nilName, true).
+		Where(EqList{"h": []int64{1, 2}}).
+		Where("i = ?", types.StructValue(
+			types.StructFieldValue("z", types.DoubleValue(1.5)),
+			types.StructFieldValue("y", types.ZeroValue(types.List(types.TypeText))),
+		))
+
+	expectedYql := `SELECT id FROM t WHERE a = Uint32("5") AND b = Int64("-3") AND c = Utf8("x\"y") AND d = String("\xFF") ` +
+		`AND e = Timestamp("2023-01-02T03:04:05.000006Z") AND f = Nothing(Optional<Utf8>) AND g = true ` +
+		`AND h IN AsList(Int64("1"), Int64("2")) ` +
+		"AND i = AsStruct(ListCreate(Utf8) AS `y`, Double(\"1.5\") AS `z`)"
+	assert.Equal(t, expectedYql, DebugYql(b))
+}
+
+func TestDebugYqlOptional(t *testing.T) {
+	name := "n"
+	b := Expr("a = ? AND b = ?", &name, types.TupleValue(types.DateValueFromTime(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))))
+	assert.Equal(t, `a = Just(Utf8("n")) AND b = AsTuple(Date("2023-01-02"))`, DebugYql(b))
+}
+
+func TestDebugYqlErrors(t *testing.T) {
+	assert.Contains(t, DebugYql(Select()), "[DebugYql error:")
+	assert.Contains(t, DebugYql(Expr("a = ? AND b = ?", 1)), "too many placeholders")
+	assert.Contains(t, DebugYql(Expr("a = ?", 1, 2)), "not enough placeholders")
+}
+
+func TestInlineYql(t *testing.T) {
+	b := Create("test").
+		Columns("id", "ts").
+		Types("Uint64", "Timestamp").
+		PrimaryKey("id").
+		Suffix("WITH (TTL = Interval(?) ON ts)", "P1D")
+
+	yql, err := InlineYql(b)
+	assert.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE test ( id Uint64, ts Timestamp, PRIMARY KEY (id) ) WITH (TTL = Interval("P1D") ON ts)`, yql)
+
+	yql, err = InlineYql(Expr("a = ? AND b = ? AND c = ?", "x\"y", []byte("\xff"), uint32(5)))
+	assert.NoError(t, err)
+	assert.Equal(t, `a = "x\"y" AND b = "\xFF" AND c = Uint32("5")`, yql)
+
+	_, err = InlineYql(Expr("a = ? AND b = ?", 1))
+	assert.ErrorContains(t, err, "too many placeholders")
+}
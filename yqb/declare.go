@@ -0,0 +1,129 @@
+package yqb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrDuplicateDeclare is returned by ToYdbSql when a param is declared twice
+// in the query, e.g. by hand in Prefix.
+var ErrDuplicateDeclare = errors.New("duplicate DECLARE")
+
+// ErrDeclareTypeMismatch is returned by ToYdbSql when a param is declared with different
+// types in the query or with a type other than the type of its value.
+var ErrDeclareTypeMismatch = errors.New("DECLARE type mismatch")
+
+// declaration is a DECLARE statement written in the query.
+type declaration struct {
+	name string
+	typ  string
+}
+
+// parseDeclares returns DECLARE statements of the query by param name,
+// string literals, comments and quoted identifiers are skipped.
+func parseDeclares(sql string) (map[string]declaration, error) {
+	declares := map[string]declaration{}
+	for i := 0; i < len(sql); {
+		if n := skipLen(sql[i:]); n > 0 {
+			i += n
+			continue
+		}
+		if !hasKeywordAt(sql, i, "DECLARE") {
+			i++
+			continue
+		}
+
+		d, n := parseDeclare(sql[i+len("DECLARE"):])
+		i += len("DECLARE") + n
+		if d.name == "" {
+			continue
+		}
+		if prev, ok := declares[d.name]; ok {
+			if !sameType(prev.typ, d.typ) {
+				return nil, fmt.Errorf("%w: %s is declared as %s and as %s",
+					ErrDeclareTypeMismatch, d.name, prev.typ, d.typ)
+			}
+			return nil, fmt.Errorf("%w: %s is declared twice", ErrDuplicateDeclare, d.name)
+		}
+		declares[d.name] = d
+	}
+	return declares, nil
+}
+
+var declareRegexp = regexp.MustCompile(`^\s+(\$[A-Za-z_][A-Za-z0-9_]*)\s+(?i:AS)\s+`)
+
+// parseDeclare parses "$name AS Type" up to the semicolon, it returns
+// the declaration and the parsed length.
+// The name is empty if the statement isn't a valid declaration.
+func parseDeclare(sql string) (declaration, int) {
+	m := declareRegexp.FindStringSubmatchIndex(sql)
+	if m == nil {
+		return declaration{}, 0
+	}
+	i := m[1]
+	for i < len(sql) && sql[i] != ';' {
+		if n := skipLen(sql[i:]); n > 0 {
+			i += n
+		} else {
+			i++
+		}
+	}
+	return declaration{name: sql[m[2]:m[3]], typ: strings.TrimSpace(sql[m[1]:i])}, i
+}
+
+func hasKeywordAt(sql string, i int, keyword string) bool {
+	if len(sql)-i < len(keyword) || !strings.EqualFold(sql[i:i+len(keyword)], keyword) {
+		return false
+	}
+	if i > 0 && isIdentByte(sql[i-1]) {
+		return false
+	}
+	end := i + len(keyword)
+	return end == len(sql) || !isIdentByte(sql[end])
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+var typeAliases = strings.NewReplacer("Text", "Utf8", "Bytes", "String")
+
+// sameType reports whether the type written in DECLARE is the type in YQL
+// returned by types.Type.Yql.
+func sameType(declared, yql string) bool {
+	declared = expandOptionals(strings.Join(strings.Fields(declared), ""))
+	yql = expandOptionals(strings.Join(strings.Fields(yql), ""))
+	return strings.EqualFold(typeAliases.Replace(declared), typeAliases.Replace(yql))
+}
+
+// expandOptionals replaces T? with Optional<T> at every nesting level of
+// the type without spaces, e.g. List<Uint64?>? is Optional<List<Optional<Uint64>>>.
+func expandOptionals(t string) string {
+	out := make([]byte, 0, len(t))
+	// starts contains the start of the current type in out for every level
+	starts := []int{0}
+	for i := 0; i < len(t); i++ {
+		switch c := t[i]; c {
+		case '?':
+			start := starts[len(starts)-1]
+			inner := string(out[start:])
+			out = append(append(append(out[:start], "Optional<"...), inner...), '>')
+		case '<', '(':
+			out = append(out, c)
+			starts = append(starts, len(out))
+		case '>', ')':
+			if len(starts) > 1 {
+				starts = starts[:len(starts)-1]
+			}
+			out = append(out, c)
+		case ',', ':':
+			out = append(out, c)
+			starts[len(starts)-1] = len(out)
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,79 @@
+package yqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOmitDeclare(t *testing.T) {
+	sb := StatementBuilder.OmitDeclare(true)
+
+	sql, params, err := sb.Select("id").From("t").Where(Eq{"a": 1}).ToYdbSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM t WHERE a = $p1", sql)
+	assert.Len(t, params, 1)
+
+	sql, _, err = sb.Delete("t").OmitDeclare(false).Where(Eq{"a": 1}).ToYdbSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS Int64;\nDELETE FROM t WHERE a = $p1", sql)
+}
+
+func TestDeclareInPrefix(t *testing.T) {
+	sql, _, err := Select("id").
+		Prefix("DECLARE $limit AS Uint64; DECLARE $p2 AS Utf8??;").
+		From("t").
+		Where(Eq{"a": 1}).
+		Where("b = ?", stringPtr("x")).
+		LimitExpr(Expr("$limit")).
+		ToYdbSql()
+	assert.NoError(t, err)
+
+	expectedSql := "DECLARE $p1 AS Int64;\n" +
+		"DECLARE $limit AS Uint64; DECLARE $p2 AS Utf8?; SELECT id FROM t WHERE a = $p1 AND b = $p2 LIMIT $limit"
+	assert.Equal(t, expectedSql, sql)
+
+	_, _, err = Select("id").
+		Prefix("DECLARE $limit AS Uint64;\ndeclare $limit as Uint64;").
+		From("t").
+		ToYdbSql()
+	assert.ErrorIs(t, err, ErrDuplicateDeclare)
+
+	_, _, err = Select("id").
+		Prefix("DECLARE $limit AS Uint64;\nDECLARE $limit AS Int64;").
+		From("t").
+		ToYdbSql()
+	assert.ErrorIs(t, err, ErrDeclareTypeMismatch)
+	assert.EqualError(t, err, "prepareYdbSqlString: DECLARE type mismatch: $limit is declared as Uint64 and as Int64")
+
+	_, _, err = Select("id").
+		Prefix("DECLARE $p1 AS Uint64;").
+		From("t").
+		Where(Eq{"a": "x"}).
+		ToYdbSql()
+	assert.ErrorIs(t, err, ErrDeclareTypeMismatch)
+
+	sql, _, err = Select("id").
+		Prefix("-- DECLARE $p1 AS Uint64;\n").
+		From("t").
+		Where("a = 'DECLARE $p1 AS Uint64;' AND b = ?", "x").
+		ToYdbSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS Utf8;\n-- DECLARE $p1 AS Uint64;\n SELECT id FROM t WHERE a = 'DECLARE $p1 AS Uint64;' AND b = $p1", sql)
+}
+
+func TestSameType(t *testing.T) {
+	assert.True(t, sameType("Optional< Text >", "Optional<Utf8>"))
+	assert.True(t, sameType("utf8??", "Optional<Optional<Utf8>>"))
+	assert.True(t, sameType("List<Bytes>", "List<String>"))
+	assert.True(t, sameType("List<Uint64?>", "List<Optional<Uint64>>"))
+	assert.True(t, sameType("List<Uint64?>?", "Optional<List<Optional<Uint64>>>"))
+	assert.True(t, sameType("Struct<'a':Int32?, 'b':Dict<Utf8, List<Text?>>>", "Struct<'a':Optional<Int32>,'b':Dict<Utf8,List<Optional<Utf8>>>>"))
+	assert.True(t, sameType("Tuple<Decimal(22,9)?, Bool>", "Tuple<Optional<Decimal(22,9)>,Bool>"))
+	assert.False(t, sameType("List<Uint64>?", "List<Optional<Uint64>>"))
+	assert.False(t, sameType("Uint64", "Int64"))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
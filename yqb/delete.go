@@ -6,20 +6,21 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
 type deleteData struct {
-	PlaceholderFormat PlaceholderFormat
-	RunWith           BaseRunner
-	Prefixes          []Sqlizer
-	From              string
-	WhereParts        []Sqlizer
-	OrderBys          []string
-	Limit             string
-	Offset            string
-	Suffixes          []Sqlizer
+	statementOptions
+
+	Prefixes   []Sqlizer
+	From       string
+	WhereParts []Sqlizer
+	OrderBys   []string
+	Limit      Sqlizer
+	Offset     Sqlizer
+	AllRows    bool
+	Returning  []string
+	Suffixes   []Sqlizer
 }
 
 func (d *deleteData) Exec() (sql.Result, error) {
@@ -48,11 +49,20 @@ func (d *deleteData) ToSql() (sqlStr string, args []any, err error) {
 	return
 }
 
+func (d *deleteData) predicateOptions() predicateOptions {
+	return predicateOptions{listParams: d.ListParams, identMode: d.IdentMode}
+}
+
 func (d *deleteData) toSqlRaw() (sqlStr string, args []any, err error) {
 	if len(d.From) == 0 {
 		err = fmt.Errorf("delete statements must specify a From table")
 		return
 	}
+	if d.SafeMode && !d.AllRows {
+		if err = checkFiltered(d.WhereParts); err != nil {
+			return
+		}
+	}
 
 	sql := &bytes.Buffer{}
 
@@ -65,30 +75,61 @@ func (d *deleteData) toSqlRaw() (sqlStr string, args []any, err error) {
 		sql.WriteString(" ")
 	}
 
+	from, err := d.IdentMode.apply(d.From)
+	if err != nil {
+		return
+	}
+
 	sql.WriteString("DELETE FROM ")
-	sql.WriteString(d.From)
+	sql.WriteString(from)
 
 	if len(d.WhereParts) > 0 {
+		var whereParts []Sqlizer
+		whereParts, err = d.predicateOptions().applyAll(d.WhereParts)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
+		args, err = appendToSql(whereParts, sql, " AND ", args)
 		if err != nil {
 			return
 		}
 	}
 
 	if len(d.OrderBys) > 0 {
+		var orderBys []string
+		orderBys, err = d.IdentMode.applyOrders(d.OrderBys)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(strings.Join(d.OrderBys, ", "))
+		sql.WriteString(strings.Join(orderBys, ", "))
 	}
 
-	if len(d.Limit) > 0 {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(d.Limit)
+	if d.Limit != nil {
+		args, err = appendLimitToSql(" LIMIT ", d.Limit, d.LimitParams, sql, args)
+		if err != nil {
+			return
+		}
 	}
 
-	if len(d.Offset) > 0 {
-		sql.WriteString(" OFFSET ")
-		sql.WriteString(d.Offset)
+	if d.Offset != nil {
+		args, err = appendLimitToSql(" OFFSET ", d.Offset, d.LimitParams, sql, args)
+		if err != nil {
+			return
+		}
+	}
+
+	if len(d.Returning) > 0 {
+		var returning []string
+		returning, err = d.IdentMode.applyColumns(d.Returning)
+		if err != nil {
+			return
+		}
+		sql.WriteString(" RETURNING ")
+		sql.WriteString(strings.Join(returning, ", "))
 	}
 
 	if len(d.Suffixes) > 0 {
@@ -107,10 +148,12 @@ func (d *deleteData) toSqlRaw() (sqlStr string, args []any, err error) {
 // Builder
 
 // DeleteBuilder builds SQL DELETE statements.
-type DeleteBuilder builder.Builder
+type DeleteBuilder struct {
+	d *deleteData
+}
 
-func init() {
-	builder.Register(DeleteBuilder{}, deleteData{})
+func (b DeleteBuilder) data() *deleteData {
+	return dataOf(b.d)
 }
 
 // Format methods
@@ -118,19 +161,81 @@ func init() {
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b DeleteBuilder) PlaceholderFormat(f PlaceholderFormat) DeleteBuilder {
-	return builder.Set(b, "PlaceholderFormat", f).(DeleteBuilder)
+	d := clone(b.d)
+	d.PlaceholderFormat = f
+	return DeleteBuilder{d}
+}
+
+// ListParams sets whether slices in Eq and NotEq predicates are bound as
+// a single List<T> parameter.
+//
+// See StatementBuilderType.ListParams.
+func (b DeleteBuilder) ListParams(enabled bool) DeleteBuilder {
+	d := clone(b.d)
+	d.ListParams = enabled
+	return DeleteBuilder{d}
+}
+
+// LimitParams sets whether numbers of LIMIT and OFFSET clauses are bound as
+// Uint64 parameters.
+//
+// See StatementBuilderType.LimitParams.
+func (b DeleteBuilder) LimitParams(enabled bool) DeleteBuilder {
+	d := clone(b.d)
+	d.LimitParams = enabled
+	return DeleteBuilder{d}
+}
+
+// IdentMode sets IdentMode (e.g. IdentQuote or IdentValidate) for table and
+// column names of the query.
+//
+// See StatementBuilderType.IdentMode.
+func (b DeleteBuilder) IdentMode(mode IdentMode) DeleteBuilder {
+	d := clone(b.d)
+	d.IdentMode = mode
+	return DeleteBuilder{d}
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b DeleteBuilder) OmitDeclare(omit bool) DeleteBuilder {
+	d := clone(b.d)
+	d.OmitDeclare = omit
+	return DeleteBuilder{d}
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b DeleteBuilder) Limits(limits Limits) DeleteBuilder {
+	d := clone(b.d)
+	d.Limits = limits
+	return DeleteBuilder{d}
+}
+
+// SafeMode sets whether ToSql returns ErrUnfilteredStatement for the query
+// without WHERE, unless AllRows is called.
+//
+// See StatementBuilderType.SafeMode.
+func (b DeleteBuilder) SafeMode(enabled bool) DeleteBuilder {
+	d := clone(b.d)
+	d.SafeMode = enabled
+	return DeleteBuilder{d}
 }
 
 // Runner methods
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b DeleteBuilder) RunWith(runner BaseRunner) DeleteBuilder {
-	return setRunWith(b, runner).(DeleteBuilder)
+	d := clone(b.d)
+	d.RunWith = wrapRunner(runner)
+	return DeleteBuilder{d}
 }
 
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b DeleteBuilder) Exec() (sql.Result, error) {
-	data := builder.GetStruct(b).(deleteData)
+	data := b.data()
 	return data.Exec()
 }
 
@@ -138,7 +243,7 @@ func (b DeleteBuilder) Exec() (sql.Result, error) {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b DeleteBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(deleteData)
+	data := b.data()
 	return data.ToSql()
 }
 
@@ -149,7 +254,7 @@ func (b DeleteBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
 	}
 
-	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args)
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, b.data().OmitDeclare)
 	if err != nil {
 		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
 	}
@@ -159,6 +264,10 @@ func (b DeleteBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
 	}
 
+	if err = checkLimits(b.data().Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
 	return ydbSqlStr, ydbArgs, err
 }
 
@@ -169,34 +278,83 @@ func (b DeleteBuilder) Prefix(sql string, args ...any) DeleteBuilder {
 
 // PrefixExpr adds an expression to the very beginning of the query
 func (b DeleteBuilder) PrefixExpr(expr Sqlizer) DeleteBuilder {
-	return builder.Append(b, "Prefixes", expr).(DeleteBuilder)
+	d := clone(b.d)
+	d.Prefixes = appendCopy(d.Prefixes, expr)
+	return DeleteBuilder{d}
 }
 
 // From sets the table to be deleted from.
 func (b DeleteBuilder) From(from string) DeleteBuilder {
-	return builder.Set(b, "From", from).(DeleteBuilder)
+	d := clone(b.d)
+	d.From = from
+	return DeleteBuilder{d}
 }
 
 // Where adds WHERE expressions to the query.
 //
 // See SelectBuilder.Where for more information.
 func (b DeleteBuilder) Where(pred any, args ...any) DeleteBuilder {
-	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(DeleteBuilder)
+	d := clone(b.d)
+	d.WhereParts = appendCopy(d.WhereParts, newWherePart(pred, args...))
+	return DeleteBuilder{d}
+}
+
+// AllRows allows the query to delete all rows without WHERE in the safe mode.
+//
+// See StatementBuilderType.SafeMode.
+func (b DeleteBuilder) AllRows() DeleteBuilder {
+	d := clone(b.d)
+	d.AllRows = true
+	return DeleteBuilder{d}
 }
 
 // OrderBy adds ORDER BY expressions to the query.
+//
+// The IdentMode of the builder is applied to their columns, e.g. "created_at DESC".
 func (b DeleteBuilder) OrderBy(orderBys ...string) DeleteBuilder {
-	return builder.Extend(b, "OrderBys", orderBys).(DeleteBuilder)
+	d := clone(b.d)
+	d.OrderBys = appendCopy(d.OrderBys, orderBys...)
+	return DeleteBuilder{d}
 }
 
 // Limit sets a LIMIT clause on the query.
 func (b DeleteBuilder) Limit(limit uint64) DeleteBuilder {
-	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(DeleteBuilder)
+	d := clone(b.d)
+	d.Limit = limitValue(limit)
+	return DeleteBuilder{d}
 }
 
 // Offset sets a OFFSET clause on the query.
 func (b DeleteBuilder) Offset(offset uint64) DeleteBuilder {
-	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(DeleteBuilder)
+	d := clone(b.d)
+	d.Offset = limitValue(offset)
+	return DeleteBuilder{d}
+}
+
+// LimitExpr sets a LIMIT clause on the query with an expression, e.g. Expr("$limit").
+func (b DeleteBuilder) LimitExpr(expr Sqlizer) DeleteBuilder {
+	d := clone(b.d)
+	d.Limit = expr
+	return DeleteBuilder{d}
+}
+
+// OffsetExpr sets a OFFSET clause on the query with an expression, e.g. Expr("$offset").
+func (b DeleteBuilder) OffsetExpr(expr Sqlizer) DeleteBuilder {
+	d := clone(b.d)
+	d.Offset = expr
+	return DeleteBuilder{d}
+}
+
+// Returning adds a RETURNING clause to the query, it returns the deleted rows.
+// Use * to return all columns.
+// The rows can be scanned with GetX and SelectX of yqe.Executor.
+// Ex:
+//
+//	Delete("users").Where(Eq{"id": 1}).Returning("*")
+func (b DeleteBuilder) Returning(columns ...string) DeleteBuilder {
+	d := clone(b.d)
+	d.Returning = appendCopy(d.Returning, columns...)
+	return DeleteBuilder{d}
 }
 
 // Suffix adds an expression to the end of the query
@@ -206,11 +364,13 @@ func (b DeleteBuilder) Suffix(sql string, args ...any) DeleteBuilder {
 
 // SuffixExpr adds an expression to the end of the query
 func (b DeleteBuilder) SuffixExpr(expr Sqlizer) DeleteBuilder {
-	return builder.Append(b, "Suffixes", expr).(DeleteBuilder)
+	d := clone(b.d)
+	d.Suffixes = appendCopy(d.Suffixes, expr)
+	return DeleteBuilder{d}
 }
 
 func (b DeleteBuilder) Query() (*sql.Rows, error) {
-	data := builder.GetStruct(b).(deleteData)
+	data := b.data()
 	return data.Query()
 }
 
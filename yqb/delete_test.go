@@ -77,3 +77,46 @@ func TestDeleteWithQuery(t *testing.T) {
 
 	assert.Equal(t, expectedSql, db.LastQuerySql)
 }
+
+func TestDeleteBuilderReturning(t *testing.T) {
+	b := Delete("table").Where(Eq{"id": 1}).Returning("*")
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSQL := "DELETE FROM table WHERE id = $p1 RETURNING *"
+	assert.Equal(t, expectedSQL, sql)
+
+	_, _, err = StatementBuilder.IdentMode(IdentValidate).Delete("table").AllRows().Returning("a b").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+}
+
+func TestDeleteBuilderSafeMode(t *testing.T) {
+	_, _, err := Delete("test").ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	_, _, err = Delete("test").Where(Or{Eq{"id": 1}, Eq{}}).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	_, _, err = Delete("test").Where(NotEq{"id": []int{}}).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	_, _, err = Delete("test").Where(NotEqList{"id": []int{}, "name": []string{}}).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	sql, _, err := Delete("test").Where(NotEq{"id": []int{}, "name": "a"}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM test WHERE (1=1) AND name <> $p1", sql)
+
+	sql, _, err = Delete("test").Where(Eq{"id": []int{}}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM test WHERE (1=0)", sql)
+
+	sql, _, err = Delete("test").AllRows().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM test", sql)
+
+	sql, _, err = Delete("test").SafeMode(false).Where(Eq{}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM test WHERE (1=1)", sql)
+}
@@ -0,0 +1,295 @@
+package yqb
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+// StatementKind is a kind of statement: read, write or DDL.
+type StatementKind string
+
+const (
+	// StatementUnknown is a statement of unknown kind.
+	StatementUnknown StatementKind = "unknown"
+	// StatementRead is a statement that only reads data, e.g. SELECT.
+	StatementRead StatementKind = "read"
+	// StatementWrite is a statement that changes data, e.g. INSERT, UPDATE or DELETE.
+	StatementWrite StatementKind = "write"
+	// StatementDDL is a statement that changes the scheme, e.g. CREATE TABLE.
+	StatementDDL StatementKind = "ddl"
+)
+
+// QueryInfo describes a query, see Describe.
+type QueryInfo struct {
+	// Query is the final query text as returned by ToYdbSql.
+	Query string
+	// Params are the query params as returned by ToYdbSql.
+	Params []table.ParameterOption
+	// Fingerprint is a hash of Query, it's the same for queries that differ only in param values.
+	Fingerprint string
+	// Kind is the statement kind.
+	Kind StatementKind
+	// ReadTables are the tables the query reads, in order of appearance.
+	ReadTables []string
+	// WriteTables are the tables the query writes or changes the scheme of.
+	WriteTables []string
+}
+
+// Describe calls ToYdbSql on b and returns the query with information about it.
+// It's useful for metrics labels, routing reads to read-only transactions and audit logs.
+//
+// Tables are known for the builders of this package, they are returned as
+// written in the builder (quoted names are unquoted), e.g. "auth/users".
+// The kind is the highest kind of the builder and of the statements of the query
+// detected by StatementKindOf, so a write statement in Prefix or Suffix isn't a read.
+// For other YdbSqlizers the tables are empty.
+// Ex:
+//
+//	info, err := Describe(Select("id").From("users").Where(Eq{"id": 1}))
+//	// info.Kind == StatementRead
+//	// info.ReadTables == []string{"users"}
+func Describe(b YdbSqlizer) (QueryInfo, error) {
+	query, params, err := b.ToYdbSql()
+	if err != nil {
+		return QueryInfo{}, err
+	}
+
+	info := QueryInfo{
+		Query:       query,
+		Params:      params,
+		Fingerprint: fingerprint(query),
+	}
+
+	// the kind of the builder is raised by the statements of the text, e.g. written in Prefix
+	info.describe(b)
+	info.addKind(StatementKindOf(query))
+
+	return info, nil
+}
+
+// describe sets the kind and the tables of the builders of this package,
+// it returns false for other queries.
+func (info *QueryInfo) describe(b any) bool {
+	switch b := b.(type) {
+	case SelectBuilder:
+		info.addKind(StatementRead)
+		info.ReadTables = appendTables(info.ReadTables, selectTables(b))
+	case InsertBuilder:
+		d := b.data()
+		info.addKind(StatementWrite)
+		info.WriteTables = appendTable(info.WriteTables, d.Into)
+		if d.Select != nil {
+			info.ReadTables = appendTables(info.ReadTables, selectTables(*d.Select))
+		}
+	case UpdateBuilder:
+		d := b.data()
+		info.addKind(StatementWrite)
+		info.WriteTables = appendTable(info.WriteTables, d.Table)
+		info.ReadTables = appendFromTables(info.ReadTables, d.From)
+	case DeleteBuilder:
+		info.addKind(StatementWrite)
+		info.WriteTables = appendTable(info.WriteTables, b.data().From)
+	case CreateBuilder:
+		info.addKind(StatementDDL)
+		info.WriteTables = appendTable(info.WriteTables, b.data().Table)
+	case DropBuilder:
+		info.addKind(StatementDDL)
+		info.WriteTables = appendTable(info.WriteTables, b.data().Table)
+	case MultiBuilder:
+		for _, q := range b.queries {
+			if info.describe(q) {
+				continue
+			}
+			query, _, _ := nestedToSql(q)
+			info.addKind(StatementKindOf(query))
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+var statementKindRanks = map[StatementKind]int{
+	StatementRead:    1,
+	StatementUnknown: 2,
+	StatementWrite:   3,
+	StatementDDL:     4,
+}
+
+// addKind sets the kind of several statements: it's a read only if all of them are reads.
+func (info *QueryInfo) addKind(kind StatementKind) {
+	if statementKindRanks[kind] > statementKindRanks[info.Kind] {
+		info.Kind = kind
+	}
+}
+
+func fingerprint(query string) string {
+	h := fnv.New64a()
+	h.Write([]byte(query))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+var joinRegexp = regexp.MustCompile(`(?i)\bJOIN\s+`)
+
+func selectTables(b SelectBuilder) []string {
+	d := b.data()
+	tables := appendFromTables(nil, d.From)
+	for _, join := range d.Joins {
+		p, ok := join.(*part)
+		if !ok {
+			continue
+		}
+		if pred, ok := p.pred.(string); ok {
+			if loc := joinRegexp.FindStringIndex(pred); loc != nil {
+				tables = appendTable(tables, pred[loc[1]:])
+			}
+		}
+	}
+	return tables
+}
+
+// appendFromTables appends the tables of a FROM clause, set by From or FromSelect.
+func appendFromTables(tables []string, from Sqlizer) []string {
+	switch from := from.(type) {
+	case *part:
+		switch pred := from.pred.(type) {
+		case string:
+			return appendTable(tables, pred)
+		case SelectBuilder:
+			return appendTables(tables, selectTables(pred))
+		}
+	case aliasExpr:
+		if sb, ok := from.expr.(SelectBuilder); ok {
+			return appendTables(tables, selectTables(sb))
+		}
+	}
+	return tables
+}
+
+func appendTables(tables []string, names []string) []string {
+	for _, name := range names {
+		tables = appendTable(tables, name)
+	}
+	return tables
+}
+
+// appendTable appends the table name found at the beginning of s, if it isn't appended yet.
+func appendTable(tables []string, s string) []string {
+	name := tableName(s)
+	if name == "" {
+		return tables
+	}
+	for _, t := range tables {
+		if t == name {
+			return tables
+		}
+	}
+	return append(tables, name)
+}
+
+// tableName returns the table name at the beginning of s, e.g. "users" for "users AS u".
+func tableName(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || s[0] == '(' {
+		return ""
+	}
+	if s[0] == '`' {
+		n := quotedIdentLen(s)
+		if n == 0 {
+			return ""
+		}
+		return unquoteIdent(s[:n])
+	}
+	return strings.Fields(s)[0]
+}
+
+// unquoteIdent reverts quoteIdent.
+func unquoteIdent(quoted string) string {
+	var sb strings.Builder
+	for i := 1; i < len(quoted)-1; i++ {
+		if quoted[i] == '\\' {
+			i++
+		}
+		sb.WriteByte(quoted[i])
+	}
+	return sb.String()
+}
+
+var statementKinds = map[string]StatementKind{
+	"SELECT":  StatementRead,
+	"INSERT":  StatementWrite,
+	"UPSERT":  StatementWrite,
+	"REPLACE": StatementWrite,
+	"UPDATE":  StatementWrite,
+	"DELETE":  StatementWrite,
+	"CREATE":  StatementDDL,
+	"ALTER":   StatementDDL,
+	"DROP":    StatementDDL,
+}
+
+// StatementKindOf returns the kind of the query by the statements that aren't DECLARE or PRAGMA,
+// e.g. to pick the retry or transaction mode of a query text. Like Describe for several
+// statements, it's a read only if all of them are reads and the highest kind otherwise.
+// String literals, comments and quoted identifiers are skipped.
+func StatementKindOf(query string) StatementKind {
+	var info QueryInfo
+	for _, keyword := range statementKeywords(query) {
+		if keyword == "DECLARE" || keyword == "PRAGMA" {
+			continue
+		}
+		kind, ok := statementKinds[keyword]
+		if !ok {
+			kind = StatementUnknown
+		}
+		info.addKind(kind)
+	}
+	if info.Kind == "" {
+		return StatementUnknown
+	}
+	return info.Kind
+}
+
+// statementKeywords returns the first words of the statements of the query in upper case,
+// the word of a statement starting with a literal or a symbol is the symbol, e.g. "(".
+func statementKeywords(query string) []string {
+	var (
+		keywords []string
+		start    = true
+	)
+	for i := 0; i < len(query); {
+		if n := skipLen(query[i:]); n > 0 {
+			isComment := strings.HasPrefix(query[i:], "--") || strings.HasPrefix(query[i:], "/*")
+			if start && !isComment {
+				keywords = append(keywords, query[i:i+1])
+				start = false
+			}
+			i += n
+			continue
+		}
+
+		c := query[i]
+		switch {
+		case c == ';':
+			start = true
+			i++
+		case !start || c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		default:
+			j := i
+			for j < len(query) && isIdentByte(query[j]) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			keywords = append(keywords, strings.ToUpper(query[i:j]))
+			start = false
+			i = j
+		}
+	}
+	return keywords
+}
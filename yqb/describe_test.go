@@ -0,0 +1,117 @@
+package yqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeSelect(t *testing.T) {
+	b := Select("u.id").
+		FromSelect(Select("*").From("`auth/users` AS u0"), "u").
+		Join("orders AS o ON o.user_id = u.id").
+		LeftJoin("(SELECT 1) AS x ON true").
+		Where(Eq{"u.id": 1})
+	info, err := Describe(b)
+	assert.NoError(t, err)
+
+	query, _, _ := b.ToYdbSql()
+	assert.Equal(t, query, info.Query)
+	assert.Len(t, info.Params, 1)
+	assert.Equal(t, StatementRead, info.Kind)
+	assert.Equal(t, []string{"auth/users", "orders"}, info.ReadTables)
+	assert.Empty(t, info.WriteTables)
+	assert.Len(t, info.Fingerprint, 16)
+
+	other, err := Describe(Select("u.id").
+		FromSelect(Select("*").From("`auth/users` AS u0"), "u").
+		Join("orders AS o ON o.user_id = u.id").
+		LeftJoin("(SELECT 1) AS x ON true").
+		Where(Eq{"u.id": 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, info.Fingerprint, other.Fingerprint)
+
+	other, err = Describe(Select("id").From("users"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, info.Fingerprint, other.Fingerprint)
+}
+
+func TestDescribeWrite(t *testing.T) {
+	info, err := Describe(Insert("users").Columns("id").Select(Select("id").From("old_users")))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementWrite, info.Kind)
+	assert.Equal(t, []string{"users"}, info.WriteTables)
+	assert.Equal(t, []string{"old_users"}, info.ReadTables)
+
+	info, err = Describe(Update("users").Set("a", 1).Where(Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementWrite, info.Kind)
+	assert.Equal(t, []string{"users"}, info.WriteTables)
+	assert.Empty(t, info.ReadTables)
+
+	info, err = Describe(Delete(Path("auth", "users")).Where(Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementWrite, info.Kind)
+	assert.Equal(t, []string{"auth/users"}, info.WriteTables)
+}
+
+func TestDescribeDDL(t *testing.T) {
+	info, err := Describe(Create("users").Columns("id").Types("Uint64").PrimaryKey("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementDDL, info.Kind)
+	assert.Equal(t, []string{"users"}, info.WriteTables)
+
+	info, err = Describe(Drop("users"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementDDL, info.Kind)
+	assert.Equal(t, []string{"users"}, info.WriteTables)
+}
+
+func TestDescribePrefixSuffix(t *testing.T) {
+	info, err := Describe(Select("id").Prefix("DELETE FROM t WHERE id = 1;").From("t"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementWrite, info.Kind)
+	assert.Equal(t, []string{"t"}, info.ReadTables)
+
+	info, err = Describe(Select("id").From("t").Suffix("; UPSERT INTO logs (id) VALUES (1)"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementWrite, info.Kind)
+
+	info, err = Describe(Update("users").Set("a", 1).Where(Eq{"id": 1}).Suffix("; DROP TABLE users"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementDDL, info.Kind)
+
+	info, err = Describe(Select("id").Prefix("PRAGMA AnsiInForEmptyOrNullableItemsCollections;").From("t"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementRead, info.Kind)
+}
+
+func TestStatementKindOf(t *testing.T) {
+	assert.Equal(t, StatementRead, StatementKindOf("DECLARE $p1 AS Uint64;\nPRAGMA x;\nselect 1"))
+	assert.Equal(t, StatementWrite, StatementKindOf("UPSERT INTO t (id) VALUES (1)"))
+	assert.Equal(t, StatementDDL, StatementKindOf("ALTER TABLE t ADD COLUMN a Uint64"))
+	assert.Equal(t, StatementUnknown, StatementKindOf("$x = 1"))
+	assert.Equal(t, StatementWrite, StatementKindOf("SELECT * FROM t; DELETE FROM t WHERE id = 1"))
+	assert.Equal(t, StatementUnknown, StatementKindOf("SELECT 1; $x = 1"))
+	assert.Equal(t, StatementRead, StatementKindOf("SELECT 1; SELECT 2;"))
+	assert.Equal(t, StatementUnknown, StatementKindOf(""))
+
+	tests := []struct {
+		query string
+		kind  StatementKind
+	}{
+		{"-- list users\nSELECT * FROM t", StatementRead},
+		{"/* x */ UPSERT INTO t (id) VALUES (1)", StatementWrite},
+		{"SELECT * FROM t WHERE s = ';DELETE'", StatementRead},
+		{"SELECT * FROM t WHERE s = \"a;b\"; -- ;DELETE FROM t\n", StatementRead},
+		{"SELECT `a;b` FROM t; /* ; DROP TABLE t */ SELECT 1", StatementRead},
+		{"SELECT 1;/* x */DELETE FROM t", StatementWrite},
+		{"'x'", StatementUnknown},
+		{"(SELECT 1)", StatementUnknown},
+		{"$x = SELECT 1; SELECT * FROM $x", StatementUnknown},
+		{"-- only a comment", StatementUnknown},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.kind, StatementKindOf(tt.query), tt.query)
+	}
+}
@@ -6,17 +6,16 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
 type dropStmt struct {
-	PlaceholderFormat PlaceholderFormat
-	RunWith           BaseRunner
-	Prefixes          []Sqlizer
-	StatementKeyword  string
-	Table             string
-	Suffixes          []Sqlizer
+	statementOptions
+
+	Prefixes         []Sqlizer
+	StatementKeyword string
+	Table            string
+	Suffixes         []Sqlizer
 }
 
 func (d *dropStmt) Exec() (sql.Result, error) {
@@ -76,8 +75,13 @@ func (d *dropStmt) toSqlRaw() (sqlStr string, args []any, err error) {
 		sql.WriteString(" ")
 	}
 
+	tableName, err := d.IdentMode.apply(d.Table)
+	if err != nil {
+		return
+	}
+
 	sql.WriteString("TABLE ")
-	sql.WriteString(d.Table)
+	sql.WriteString(tableName)
 
 	if err != nil {
 		return
@@ -99,10 +103,12 @@ func (d *dropStmt) toSqlRaw() (sqlStr string, args []any, err error) {
 // Builder
 
 // DropBuilder builds SQL DROP statements.
-type DropBuilder builder.Builder
+type DropBuilder struct {
+	d *dropStmt
+}
 
-func init() {
-	builder.Register(DropBuilder{}, dropStmt{})
+func (b DropBuilder) data() *dropStmt {
+	return dataOf(b.d)
 }
 
 // Format methods
@@ -110,25 +116,57 @@ func init() {
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b DropBuilder) PlaceholderFormat(f PlaceholderFormat) DropBuilder {
-	return builder.Set(b, "PlaceholderFormat", f).(DropBuilder)
+	d := clone(b.d)
+	d.PlaceholderFormat = f
+	return DropBuilder{d}
+}
+
+// IdentMode sets IdentMode (e.g. IdentQuote or IdentValidate) for table and
+// column names of the query.
+//
+// See StatementBuilderType.IdentMode.
+func (b DropBuilder) IdentMode(mode IdentMode) DropBuilder {
+	d := clone(b.d)
+	d.IdentMode = mode
+	return DropBuilder{d}
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b DropBuilder) OmitDeclare(omit bool) DropBuilder {
+	d := clone(b.d)
+	d.OmitDeclare = omit
+	return DropBuilder{d}
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b DropBuilder) Limits(limits Limits) DropBuilder {
+	d := clone(b.d)
+	d.Limits = limits
+	return DropBuilder{d}
 }
 
 // Runner methods
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b DropBuilder) RunWith(runner BaseRunner) DropBuilder {
-	return setRunWith(b, runner).(DropBuilder)
+	d := clone(b.d)
+	d.RunWith = wrapRunner(runner)
+	return DropBuilder{d}
 }
 
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b DropBuilder) Exec() (sql.Result, error) {
-	data := builder.GetStruct(b).(dropStmt)
+	data := b.data()
 	return data.Exec()
 }
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b DropBuilder) Query() (*sql.Rows, error) {
-	data := builder.GetStruct(b).(dropStmt)
+	data := b.data()
 	return data.Query()
 }
 
@@ -136,10 +174,15 @@ func (b DropBuilder) Query() (*sql.Rows, error) {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b DropBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(dropStmt)
+	data := b.data()
 	return data.ToSql()
 }
 
+func (b DropBuilder) toSqlRaw() (string, []any, error) {
+	data := b.data()
+	return data.toSqlRaw()
+}
+
 // ToYdbSql builds the query into a SQL string and bound args.
 func (b DropBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 	sqlStr, args, err := b.ToSql()
@@ -147,7 +190,7 @@ func (b DropBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
 	}
 
-	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args)
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, b.data().OmitDeclare)
 	if err != nil {
 		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
 	}
@@ -157,6 +200,10 @@ func (b DropBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
 	}
 
+	if err = checkLimits(b.data().Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
 	return ydbSqlStr, ydbArgs, err
 }
 
@@ -167,12 +214,16 @@ func (b DropBuilder) Prefix(sql string, args ...any) DropBuilder {
 
 // PrefixExpr adds an expression to the very beginning of the query
 func (b DropBuilder) PrefixExpr(expr Sqlizer) DropBuilder {
-	return builder.Append(b, "Prefixes", expr).(DropBuilder)
+	d := clone(b.d)
+	d.Prefixes = appendCopy(d.Prefixes, expr)
+	return DropBuilder{d}
 }
 
 // Table sets the TABLE clause of the query.
 func (b DropBuilder) Table(table string) DropBuilder {
-	return builder.Set(b, "Table", table).(DropBuilder)
+	d := clone(b.d)
+	d.Table = table
+	return DropBuilder{d}
 }
 
 // Suffix adds an expression to the end of the query
@@ -182,5 +233,7 @@ func (b DropBuilder) Suffix(sql string, args ...any) DropBuilder {
 
 // SuffixExpr adds an expression to the end of the query
 func (b DropBuilder) SuffixExpr(expr Sqlizer) DropBuilder {
-	return builder.Append(b, "Suffixes", expr).(DropBuilder)
+	d := clone(b.d)
+	d.Suffixes = appendCopy(d.Suffixes, expr)
+	return DropBuilder{d}
 }
@@ -141,7 +141,7 @@ func (e aliasExpr) ToSql() (sql string, args []any, err error) {
 // Eq is syntactic sugar for use with Where/Having/Set methods.
 type Eq map[string]any
 
-func (eq Eq) toSQL(useNotOpr bool) (sql string, args []any, err error) {
+func (eq Eq) toSQL(useNotOpr, useListParam bool) (sql string, args []any, err error) {
 	if len(eq) == 0 {
 		// Empty Sql{} evaluates to true.
 		sql = sqlTrue
@@ -191,12 +191,24 @@ func (eq Eq) toSQL(useNotOpr bool) (sql string, args []any, err error) {
 
 		if val == nil {
 			expr = fmt.Sprintf("%s %s NULL", key, nullOpr)
+		} else if sqlizerVal, ok := val.(Sqlizer); ok {
+			var valSql string
+			var valArgs []any
+			if valSql, valArgs, err = sqlizerValueToSql(sqlizerVal); err != nil {
+				return
+			}
+			expr = fmt.Sprintf("%s %s %s", key, equalOpr, valSql)
+			args = append(args, valArgs...)
 		} else {
 			if isListType(val) {
+				_, isSlot := val.(SlotArg)
 				switch {
 				case isYdbVal:
 					expr = fmt.Sprintf("%s %s ?", key, inOpr)
 					args = append(args, ydbVal)
+				case isSlot:
+					expr = fmt.Sprintf("%s %s ?", key, inOpr)
+					args = append(args, val)
 				default:
 					valVal := reflect.ValueOf(val)
 					if valVal.Len() == 0 {
@@ -204,6 +216,13 @@ func (eq Eq) toSQL(useNotOpr bool) (sql string, args []any, err error) {
 						if args == nil {
 							args = []any{}
 						}
+					} else if useListParam {
+						var listVal types.Value
+						if listVal, err = listValue(valVal); err != nil {
+							return
+						}
+						expr = fmt.Sprintf("%s %s ?", key, inOpr)
+						args = append(args, listVal)
 					} else {
 						for i := 0; i < valVal.Len(); i++ {
 							args = append(args, valVal.Index(i).Interface())
@@ -223,7 +242,7 @@ func (eq Eq) toSQL(useNotOpr bool) (sql string, args []any, err error) {
 }
 
 func (eq Eq) ToSql() (sql string, args []any, err error) {
-	return eq.toSQL(false)
+	return eq.toSQL(false, false)
 }
 
 // NotEq is syntactic sugar for use with Where/Having/Set methods.
@@ -233,7 +252,37 @@ func (eq Eq) ToSql() (sql string, args []any, err error) {
 type NotEq Eq
 
 func (neq NotEq) ToSql() (sql string, args []any, err error) {
-	return Eq(neq).toSQL(true)
+	return Eq(neq).toSQL(true, false)
+}
+
+// EqList is like Eq, but binds slice and array values as a single List<T>
+// parameter instead of one parameter per item, so the query text doesn't depend
+// on the number of items.
+// Empty lists are rendered as the constant false expression, which matches
+// the AnsiInForEmptyOrNullableItemsCollections semantics for empty collections.
+// Lists of nullable items are bound as List<Optional<T>>, add
+// "PRAGMA AnsiInForEmptyOrNullableItemsCollections;" with Prefix to get the
+// ANSI behavior for NULL items.
+// Ex:
+//
+//	.Where(EqList{"id": []int64{1, 2, 3}}) == "id IN $p1"
+type EqList Eq
+
+func (eql EqList) ToSql() (sql string, args []any, err error) {
+	return Eq(eql).toSQL(false, true)
+}
+
+// NotEqList is like NotEq, but binds slice and array values as a single
+// List<T> parameter.
+//
+// See EqList for more information.
+// Ex:
+//
+//	.Where(NotEqList{"id": []int64{1, 2, 3}}) == "id NOT IN $p1"
+type NotEqList Eq
+
+func (neql NotEqList) ToSql() (sql string, args []any, err error) {
+	return Eq(neql).toSQL(true, true)
 }
 
 // Like is syntactic sugar for use with LIKE conditions.
@@ -257,6 +306,14 @@ func (lk Like) toSql(opr string) (sql string, args []any, err error) {
 		if val == nil {
 			err = fmt.Errorf("cannot use null with like operators")
 			return
+		} else if sqlizerVal, ok := val.(Sqlizer); ok {
+			var valSql string
+			var valArgs []any
+			if valSql, valArgs, err = sqlizerValueToSql(sqlizerVal); err != nil {
+				return
+			}
+			expr = fmt.Sprintf("%s %s %s", key, opr, valSql)
+			args = append(args, valArgs...)
 		} else {
 			if isListType(val) {
 				err = fmt.Errorf("cannot use array or slice with like operators")
@@ -306,6 +363,50 @@ func (nilk NotILike) ToSql() (sql string, args []any, err error) {
 	return Like(nilk).toSql("NOT ILIKE")
 }
 
+// StartsWith is syntactic sugar for the YQL StartsWith function, it matches
+// strings with the prefix without escaping LIKE wildcards.
+// Ex:
+//
+//	.Where(StartsWith{"name": "sq"}) == "StartsWith(name, ?)"
+type StartsWith map[string]any
+
+func (sw StartsWith) ToSql() (sql string, args []any, err error) {
+	var exprs []string
+	for _, key := range getSortedKeys(sw) {
+		val := sw[key]
+
+		switch v := val.(type) {
+		case driver.Valuer:
+			if val, err = v.Value(); err != nil {
+				return
+			}
+		}
+
+		if val == nil {
+			err = fmt.Errorf("cannot use null with StartsWith")
+			return
+		}
+		if sqlizerVal, ok := val.(Sqlizer); ok {
+			var valSql string
+			var valArgs []any
+			if valSql, valArgs, err = sqlizerValueToSql(sqlizerVal); err != nil {
+				return
+			}
+			exprs = append(exprs, fmt.Sprintf("StartsWith(%s, %s)", key, valSql))
+			args = append(args, valArgs...)
+			continue
+		}
+		if isListType(val) {
+			err = fmt.Errorf("cannot use array or slice with StartsWith")
+			return
+		}
+		exprs = append(exprs, fmt.Sprintf("StartsWith(%s, ?)", key))
+		args = append(args, val)
+	}
+	sql = strings.Join(exprs, " AND ")
+	return
+}
+
 // Lt is syntactic sugar for use with Where/Having/Set methods.
 // Ex:
 //
@@ -342,12 +443,22 @@ func (lt Lt) toSql(opposite, orEq bool) (sql string, args []any, err error) {
 			err = fmt.Errorf("cannot use null with less than or greater than operators")
 			return
 		}
-		if isListType(val) {
-			err = fmt.Errorf("cannot use array or slice with less than or greater than operators")
-			return
+		if sqlizerVal, ok := val.(Sqlizer); ok {
+			var valSql string
+			var valArgs []any
+			if valSql, valArgs, err = sqlizerValueToSql(sqlizerVal); err != nil {
+				return
+			}
+			expr = fmt.Sprintf("%s %s %s", key, opr, valSql)
+			args = append(args, valArgs...)
+		} else {
+			if isListType(val) {
+				err = fmt.Errorf("cannot use array or slice with less than or greater than operators")
+				return
+			}
+			expr = fmt.Sprintf("%s %s ?", key, opr)
+			args = append(args, val)
 		}
-		expr = fmt.Sprintf("%s %s ?", key, opr)
-		args = append(args, val)
 
 		exprs = append(exprs, expr)
 	}
@@ -426,6 +537,19 @@ func (o Or) ToSql() (string, []any, error) {
 	return conj(o).join(" OR ", sqlFalse)
 }
 
+// sqlizerValueToSql renders a Sqlizer used as a value of Eq, Lt or Like,
+// subqueries are wrapped in parentheses.
+func sqlizerValueToSql(s Sqlizer) (sql string, args []any, err error) {
+	sql, args, err = nestedToSql(s)
+	if err != nil {
+		return
+	}
+	if _, ok := s.(SelectBuilder); ok {
+		sql = fmt.Sprintf("(%s)", sql)
+	}
+	return
+}
+
 func getSortedKeys(exp map[string]any) []string {
 	sortedKeys := make([]string, 0, len(exp))
 	for k := range exp {
@@ -435,11 +559,37 @@ func getSortedKeys(exp map[string]any) []string {
 	return sortedKeys
 }
 
+// listValue casts items of a slice or array into a single YDB List value.
+func listValue(val reflect.Value) (types.Value, error) {
+	items := make([]types.Value, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		item, ok := val.Index(i).Interface().(types.Value)
+		if !ok {
+			castedItems, err := castArgToYdb(val.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("castArgToYdb: %w", err)
+			}
+			item = castedItems[0]
+		}
+		if len(items) > 0 && !types.Equal(items[0].Type(), item.Type()) {
+			return nil, fmt.Errorf(
+				"list items must have the same type, got %s and %s",
+				items[0].Type().Yql(), item.Type().Yql(),
+			)
+		}
+		items = append(items, item)
+	}
+	return types.ListValue(items...), nil
+}
+
 func isListType(val any) bool {
 	ydbType, ok := val.(types.Value)
 	if ok {
 		return strings.Contains(ydbType.Type().Yql(), "List")
 	}
+	if s, ok := val.(SlotArg); ok {
+		return s.isList()
+	}
 	if driver.IsValue(val) {
 		return false
 	}
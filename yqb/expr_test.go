@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 )
 
 func TestConcatExpr(t *testing.T) {
@@ -107,6 +108,51 @@ func TestNotEqInEmptyToSql(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestEqListToSql(t *testing.T) {
+	b := EqList{"id": []int64{1, 2, 3}, "name": "n"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "id IN ? AND name = ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{
+		types.ListValue(types.Int64Value(1), types.Int64Value(2), types.Int64Value(3)),
+		"n",
+	}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestNotEqListToSql(t *testing.T) {
+	b := NotEqList{"id": []string{"a", "b"}}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "id NOT IN ?"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{types.ListValue(types.TextValue("a"), types.TextValue("b"))}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestEqListEmptyToSql(t *testing.T) {
+	sql, args, err := EqList{"id": []int64{}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(1=0)", sql)
+	assert.Empty(t, args)
+
+	sql, args, err = NotEqList{"id": []int64{}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "(1=1)", sql)
+	assert.Empty(t, args)
+}
+
+func TestEqListMixedTypesToSql(t *testing.T) {
+	_, _, err := EqList{"id": []any{1, "2"}}.ToSql()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "list items must have the same type")
+}
+
 func TestEqBytesToSql(t *testing.T) {
 	b := Eq{"id": []byte("test")}
 	sql, args, err := b.ToSql()
@@ -385,6 +431,21 @@ func TestNotILikeToSql(t *testing.T) {
 	assert.Equal(t, expectedArgs, args)
 }
 
+func TestStartsWithToSql(t *testing.T) {
+	b := StartsWith{"name": "sq%", "email": "a"}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "StartsWith(email, ?) AND StartsWith(name, ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{"a", "sq%"}
+	assert.Equal(t, expectedArgs, args)
+
+	_, _, err = StartsWith{"name": nil}.ToSql()
+	assert.Error(t, err)
+}
+
 func TestSqlEqOrder(t *testing.T) {
 	b := Eq{"a": 1, "b": 2, "c": 3}
 	sql, args, err := b.ToSql()
@@ -462,3 +523,21 @@ func ExampleEq() {
 		"company": 20,
 	})
 }
+
+func TestSqlizerValueToSql(t *testing.T) {
+	b := And{
+		Eq{"a": Expr("CAST(? AS Uint32)", "1")},
+		Lt{"b": Expr("CurrentUtcTimestamp()")},
+		Like{"c": Expr("? || '%'", "x")},
+		NotEq{"d": Select("id").From("t").Where("e = ?", 2)},
+	}
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "(a = CAST(? AS Uint32) AND b < CurrentUtcTimestamp() AND c LIKE ? || '%' AND " +
+		"d <> (SELECT id FROM t WHERE e = ?))"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{"1", "x", 2}
+	assert.Equal(t, expectedArgs, args)
+}
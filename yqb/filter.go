@@ -0,0 +1,143 @@
+package yqb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidFilter is returned by ToSql of WhereStruct for filters with invalid tags or values.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// filterOps are the operators of the filter tag and the predicates they build.
+var filterOps = map[string]func(column string, val any) Sqlizer{
+	"eq":     func(column string, val any) Sqlizer { return Eq{column: val} },
+	"ne":     func(column string, val any) Sqlizer { return NotEq{column: val} },
+	"lt":     func(column string, val any) Sqlizer { return Lt{column: val} },
+	"lte":    func(column string, val any) Sqlizer { return LtOrEq{column: val} },
+	"gt":     func(column string, val any) Sqlizer { return Gt{column: val} },
+	"gte":    func(column string, val any) Sqlizer { return GtOrEq{column: val} },
+	"in":     func(column string, val any) Sqlizer { return Eq{column: val} },
+	"not_in": func(column string, val any) Sqlizer { return NotEq{column: val} },
+	"like":   func(column string, val any) Sqlizer { return Like{column: val} },
+	"ilike":  func(column string, val any) Sqlizer { return ILike{column: val} },
+	"prefix": func(column string, val any) Sqlizer { return StartsWith{column: val} },
+}
+
+type errSqlizer struct {
+	err error
+}
+
+func (e errSqlizer) ToSql() (string, []any, error) {
+	return "", nil, e.err
+}
+
+// WhereStruct builds a WHERE predicate from the fields of a filter struct
+// with the filter tag, the predicates of the fields are joined with AND.
+//
+// The tag is "column,op", where op is one of eq (the default), ne, lt, lte,
+// gt, gte, in, not_in, like, ilike and prefix. Fields with nil, zero values
+// and empty slices are skipped, use pointers to filter by zero values.
+// Embedded structs and non-nil pointers to structs without the tag are walked
+// as part of the filter.
+// Invalid tags and values are returned as ErrInvalidFilter by ToSql.
+// Ex:
+//
+//	type UserFilter struct {
+//		CreatedFrom *time.Time `filter:"created_at,gte"`
+//		Statuses    []string   `filter:"status,in"`
+//		Name        string     `filter:"name,prefix"`
+//	}
+//
+//	Select("*").From("users").Where(WhereStruct(UserFilter{Name: "Jo"}))
+//	// SELECT * FROM users WHERE (StartsWith(name, $p1))
+func WhereStruct(filter any) Sqlizer {
+	v := reflect.ValueOf(filter)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return And{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return errSqlizer{fmt.Errorf("%w: %T is not a struct", ErrInvalidFilter, filter)}
+	}
+
+	preds, err := appendFilterPreds(nil, v)
+	if err != nil {
+		return errSqlizer{err}
+	}
+	return And(preds)
+}
+
+func appendFilterPreds(preds []Sqlizer, v reflect.Value) ([]Sqlizer, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("filter")
+		if !ok {
+			if embedded, ok := embeddedStruct(field, v.Field(i)); ok {
+				var err error
+				if preds, err = appendFilterPreds(preds, embedded); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		column, op, _ := strings.Cut(tag, ",")
+		if op == "" {
+			op = "eq"
+		}
+		newPred, ok := filterOps[op]
+		if column == "" || !ok {
+			return nil, fmt.Errorf("%w: tag %q of field %s", ErrInvalidFilter, tag, field.Name)
+		}
+
+		val, ok := filterValue(v.Field(i))
+		if !ok {
+			continue
+		}
+		if (op == "in" || op == "not_in") != isListType(val) {
+			return nil, fmt.Errorf("%w: %s value of field %s for %q", ErrInvalidFilter, field.Type, field.Name, op)
+		}
+		preds = append(preds, newPred(column, val))
+	}
+	return preds, nil
+}
+
+// embeddedStruct returns the struct of an embedded struct or non-nil pointer to a struct field.
+func embeddedStruct(field reflect.StructField, v reflect.Value) (reflect.Value, bool) {
+	if !field.Anonymous {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+// filterValue returns the value of the field, dereferencing pointers.
+// It returns false for nil, zero values and empty slices.
+func filterValue(v reflect.Value) (any, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		return v.Elem().Interface(), true
+	}
+	switch {
+	case v.IsZero():
+		return nil, false
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() == 0:
+		return nil, false
+	}
+	return v.Interface(), true
+}
@@ -0,0 +1,115 @@
+package yqb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type pageFilter struct {
+	AfterID uint64 `filter:"id,gt"`
+}
+
+type userFilter struct {
+	pageFilter
+	CreatedFrom *time.Time `filter:"created_at,gte"`
+	Statuses    []string   `filter:"status,in"`
+	Name        string     `filter:"name,prefix"`
+	Email       string     `filter:"email"`
+	Blocked     *bool      `filter:"blocked,eq"`
+	Role        string     `filter:"-"`
+	Limit       int
+}
+
+func TestWhereStruct(t *testing.T) {
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	blocked := false
+	filter := userFilter{
+		pageFilter:  pageFilter{AfterID: 10},
+		CreatedFrom: &created,
+		Statuses:    []string{"active", "new"},
+		Name:        "Jo",
+		Blocked:     &blocked,
+		Role:        "admin",
+		Limit:       20,
+	}
+
+	sql, args, err := Select("id").From("users").Where(WhereStruct(&filter)).ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id FROM users WHERE (id > $p1 AND created_at >= $p2 AND status IN ($p3,$p4) " +
+		"AND StartsWith(name, $p5) AND blocked = $p6)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{
+		types.Uint64Value(10),
+		types.TimestampValueFromTime(created),
+		types.UTF8Value("active"),
+		types.UTF8Value("new"),
+		types.UTF8Value("Jo"),
+		types.BoolValue(false),
+	}
+	assert.Equal(t, expectedArgs, args)
+}
+
+type orderFilter struct {
+	*pageFilter
+	UserID uint64 `filter:"user_id"`
+}
+
+func TestWhereStructEmbeddedPointer(t *testing.T) {
+	sql, args, err := Select("id").From("orders").Where(WhereStruct(orderFilter{
+		pageFilter: &pageFilter{AfterID: 10},
+		UserID:     1,
+	})).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM orders WHERE (id > $p1 AND user_id = $p2)", sql)
+	assert.Equal(t, []any{types.Uint64Value(10), types.Uint64Value(1)}, args)
+
+	sql, _, err = Select("id").From("orders").Where(WhereStruct(orderFilter{UserID: 1})).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM orders WHERE (user_id = $p1)", sql)
+}
+
+func TestWhereStructEmpty(t *testing.T) {
+	sql, args, err := Select("id").From("users").Where(WhereStruct(userFilter{Statuses: []string{}})).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users WHERE (1=1)", sql)
+	assert.Empty(t, args)
+
+	_, _, err = Delete("users").Where(WhereStruct((*userFilter)(nil))).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+}
+
+func TestWhereStructOptions(t *testing.T) {
+	sql, _, err := StatementBuilder.ListParams(true).IdentMode(IdentQuote).
+		Update("users").Set("blocked", true).
+		Where(WhereStruct(userFilter{Statuses: []string{"new"}, Email: "a@b.c"})).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `users` SET `blocked` = $p1 WHERE (`status` IN $p2 AND `email` = $p3)", sql)
+}
+
+func TestWhereStructErrors(t *testing.T) {
+	tests := []any{
+		1,
+		struct {
+			ID int `filter:"id,between"`
+		}{1},
+		struct {
+			ID int `filter:",gt"`
+		}{1},
+		struct {
+			IDs int `filter:"id,in"`
+		}{1},
+		struct {
+			IDs []int `filter:"id,gt"`
+		}{[]int{1}},
+	}
+	for _, filter := range tests {
+		_, _, err := Select("id").From("users").Where(WhereStruct(filter)).ToSql()
+		assert.ErrorIs(t, err, ErrInvalidFilter, "%#v", filter)
+	}
+}
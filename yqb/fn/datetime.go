@@ -0,0 +1,82 @@
+package fn
+
+// DateTime returns a call of the DateTime UDF with the given name.
+// Ex:
+//
+//	DateTime("GetHour", "created_at") == "DateTime::GetHour(created_at)"
+func DateTime(name string, args ...any) Func {
+	return Call("DateTime::"+name, args...)
+}
+
+// DateTimeMakeDate returns a DateTime::MakeDate function call.
+func DateTimeMakeDate(expr any) Func {
+	return DateTime("MakeDate", expr)
+}
+
+// DateTimeMakeDatetime returns a DateTime::MakeDatetime function call.
+func DateTimeMakeDatetime(expr any) Func {
+	return DateTime("MakeDatetime", expr)
+}
+
+// DateTimeMakeTimestamp returns a DateTime::MakeTimestamp function call.
+func DateTimeMakeTimestamp(expr any) Func {
+	return DateTime("MakeTimestamp", expr)
+}
+
+// DateTimeGetYear returns a DateTime::GetYear function call.
+func DateTimeGetYear(expr any) Func {
+	return DateTime("GetYear", expr)
+}
+
+// DateTimeGetMonth returns a DateTime::GetMonth function call.
+func DateTimeGetMonth(expr any) Func {
+	return DateTime("GetMonth", expr)
+}
+
+// DateTimeGetDayOfMonth returns a DateTime::GetDayOfMonth function call.
+func DateTimeGetDayOfMonth(expr any) Func {
+	return DateTime("GetDayOfMonth", expr)
+}
+
+// DateTimeStartOfDay returns a DateTime::StartOfDay function call.
+func DateTimeStartOfDay(expr any) Func {
+	return DateTime("StartOfDay", expr)
+}
+
+// DateTimeStartOfWeek returns a DateTime::StartOfWeek function call.
+func DateTimeStartOfWeek(expr any) Func {
+	return DateTime("StartOfWeek", expr)
+}
+
+// DateTimeStartOfMonth returns a DateTime::StartOfMonth function call.
+func DateTimeStartOfMonth(expr any) Func {
+	return DateTime("StartOfMonth", expr)
+}
+
+// DateTimeStartOf returns a DateTime::StartOf function call, interval is an Interval expression.
+func DateTimeStartOf(expr, interval any) Func {
+	return DateTime("StartOf", expr, interval)
+}
+
+// DateTimeShiftMonths returns a DateTime::ShiftMonths function call.
+func DateTimeShiftMonths(expr, months any) Func {
+	return DateTime("ShiftMonths", expr, months)
+}
+
+// DateTimeToSeconds returns a DateTime::ToSeconds function call.
+func DateTimeToSeconds(expr any) Func {
+	return DateTime("ToSeconds", expr)
+}
+
+// DateTimeFromSeconds returns a DateTime::FromSeconds function call.
+func DateTimeFromSeconds(expr any) Func {
+	return DateTime("FromSeconds", expr)
+}
+
+// DateTimeFormat returns a call of the formatter created by DateTime::Format.
+// Ex:
+//
+//	DateTimeFormat("%Y-%m-%d", "created_at") == `DateTime::Format("%Y-%m-%d")(created_at)`
+func DateTimeFormat(format string, expr any) Func {
+	return Call("DateTime::Format("+QuoteString(format)+")", expr)
+}
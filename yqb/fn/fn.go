@@ -0,0 +1,169 @@
+// Package fn contains builders for common YQL built-in functions.
+//
+// Every builder implements yqb.Sqlizer, so the result can be used as a column,
+// with yqb.Alias, as an argument of yqb.Expr or as a value of yqb.Eq, yqb.Lt and
+// other predicates.
+//
+// Arguments of the builders follow one rule:
+//
+// string - SQL expression (e.g. column name), written as is.
+//
+// yqb.Sqlizer - nested expression (e.g. other function), yqb.SelectBuilder is a subquery in parentheses.
+//
+// nil - NULL.
+//
+// any other value - bound as a parameter, use Param to bind a string.
+//
+// Ex:
+//
+//	yqb.Select("id").
+//		Column(yqb.Alias(fn.Coalesce("name", fn.Param("unknown")), "name")).
+//		From("users").
+//		Where(yqb.Eq{"status": fn.Cast(fn.Param("1"), types.TypeUint32)})
+package fn
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// Func is a call of a YQL function.
+type Func struct {
+	name string
+	args []any
+}
+
+// Call returns a call of the YQL function with the given name.
+// Ex:
+//
+//	Call("Digest::Md5Hex", "name") == "Digest::Md5Hex(name)"
+func Call(name string, args ...any) Func {
+	return Func{name: name, args: args}
+}
+
+// ToSql implements yqb.Sqlizer.
+func (f Func) ToSql() (sql string, args []any, err error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(f.name)
+	buf.WriteString("(")
+	args, err = appendArgsToSql(buf, ", ", f.args, args)
+	if err != nil {
+		return
+	}
+	buf.WriteString(")")
+
+	sql = buf.String()
+	return
+}
+
+// Param binds a value as a parameter, even if it's a string.
+// Ex:
+//
+//	Coalesce("name", Param("unknown")) == "COALESCE(name, ?)"
+func Param(value any) yqb.Sqlizer {
+	return yqb.Expr("?", value)
+}
+
+type castExpr struct {
+	expr any
+	to   types.Type
+}
+
+// Cast returns a CAST expression to the given type.
+// Ex:
+//
+//	Cast("id", types.TypeUint32) == "CAST(id AS Uint32)"
+func Cast(expr any, to types.Type) yqb.Sqlizer {
+	return castExpr{expr: expr, to: to}
+}
+
+func (c castExpr) ToSql() (sql string, args []any, err error) {
+	if c.to == nil {
+		err = fmt.Errorf("cast expression must specify a type")
+		return
+	}
+
+	sql, args, err = argToSql(c.expr)
+	if err != nil {
+		return
+	}
+
+	sql = fmt.Sprintf("CAST(%s AS %s)", sql, c.to.Yql())
+	return
+}
+
+// Coalesce returns a COALESCE function call.
+// Ex:
+//
+//	Coalesce("name", Param("unknown")) == "COALESCE(name, ?)"
+func Coalesce(exprs ...any) Func {
+	return Call("COALESCE", exprs...)
+}
+
+// If returns an IF function call.
+// Ex:
+//
+//	If(yqb.Gt{"age": 18}, Param("adult"), Param("child")) == "IF(age > ?, ?, ?)"
+func If(cond, then, otherwise any) Func {
+	return Call("IF", cond, then, otherwise)
+}
+
+// Unwrap returns an Unwrap function call, message is optional.
+// Ex:
+//
+//	Unwrap("name") == "Unwrap(name)"
+//	Unwrap("name", Param("name is null")) == "Unwrap(name, ?)"
+func Unwrap(expr any, message ...any) Func {
+	return Call("Unwrap", append([]any{expr}, message...)...)
+}
+
+// StartsWith returns a StartsWith function call.
+// Ex:
+//
+//	StartsWith("name", Param("Jo")) == "StartsWith(name, ?)"
+func StartsWith(expr, prefix any) Func {
+	return Call("StartsWith", expr, prefix)
+}
+
+// EndsWith returns an EndsWith function call.
+// Ex:
+//
+//	EndsWith("name", Param("hn")) == "EndsWith(name, ?)"
+func EndsWith(expr, suffix any) Func {
+	return Call("EndsWith", expr, suffix)
+}
+
+func argToSql(arg any) (string, []any, error) {
+	switch a := arg.(type) {
+	case nil:
+		return "NULL", nil, nil
+	case string:
+		return a, nil, nil
+	case yqb.SelectBuilder:
+		sql, args, err := yqb.NestedToSql(a)
+		return "(" + sql + ")", args, err
+	case yqb.Sqlizer:
+		return yqb.NestedToSql(a)
+	default:
+		return "?", []any{a}, nil
+	}
+}
+
+func appendArgsToSql(buf *bytes.Buffer, sep string, fnArgs []any, args []any) ([]any, error) {
+	for i, arg := range fnArgs {
+		argSql, argArgs, err := argToSql(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteString(argSql)
+		args = append(args, argArgs...)
+	}
+	return args, nil
+}
@@ -0,0 +1,149 @@
+package fn
+
+import (
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestCallToSql(t *testing.T) {
+	sql, args, err := Call("Digest::Md5Hex", "name", 1, nil, Param("s")).ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "Digest::Md5Hex(name, ?, NULL, ?)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{1, "s"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestCastToSql(t *testing.T) {
+	sql, args, err := Cast(Param("5"), types.TypeUint32).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CAST(? AS Uint32)", sql)
+	assert.Equal(t, []any{"5"}, args)
+
+	sql, _, err = Cast("id", types.Optional(types.TypeText)).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CAST(id AS Optional<Utf8>)", sql)
+
+	_, _, err = Cast("id", nil).ToSql()
+	assert.Error(t, err)
+}
+
+func TestCoalesceIfUnwrapToSql(t *testing.T) {
+	sql, args, err := If(yqb.Gt{"age": 18}, Coalesce("name", Param("adult")), Unwrap("nick", Param("no nick"))).ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "IF(age > ?, COALESCE(name, ?), Unwrap(nick, ?))"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{18, "adult", "no nick"}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestJSONValueToSql(t *testing.T) {
+	b := JSONValue("payload", `$.a."b c"`).
+		Passing("x", 1).
+		Returning(types.TypeUint32).
+		OnEmpty(JSONDefault(0)).
+		OnError(JSONNull)
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := `JSON_VALUE(payload, "$.a.\"b c\"" PASSING ? AS x RETURNING Uint32 DEFAULT ? ON EMPTY NULL ON ERROR)`
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{1, 0}
+	assert.Equal(t, expectedArgs, args)
+
+	_, _, err = JSONValue("payload", "$.a").Passing("x y", 1).ToSql()
+	assert.Error(t, err)
+}
+
+func TestJSONClauseOrder(t *testing.T) {
+	sql, args, err := JSONValue("payload", "$.a").
+		OnError(JSONNull).
+		OnEmpty(JSONNull).
+		Returning(types.TypeUint32).
+		OnEmpty(JSONDefault(0)).
+		Returning(types.TypeUint64).
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `JSON_VALUE(payload, "$.a" RETURNING Uint64 DEFAULT ? ON EMPTY NULL ON ERROR)`, sql)
+	assert.Equal(t, []any{0}, args)
+
+	q := JSONQuery("payload", "$.items").OnError(JSONEmptyObject).WithWrapper()
+	sql, _, err = q.WithConditionalWrapper().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `JSON_QUERY(payload, "$.items" WITH CONDITIONAL WRAPPER EMPTY OBJECT ON ERROR)`, sql)
+
+	sql, _, err = q.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `JSON_QUERY(payload, "$.items" WITH WRAPPER EMPTY OBJECT ON ERROR)`, sql)
+}
+
+func TestJSONExistsQueryToSql(t *testing.T) {
+	sql, _, err := JSONExists("payload", "$.a").OnError(JSONFalse).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `JSON_EXISTS(payload, "$.a" FALSE ON ERROR)`, sql)
+
+	sql, _, err = JSONQuery("payload", "$.items").WithWrapper().OnEmpty(JSONEmptyArray).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `JSON_QUERY(payload, "$.items" WITH WRAPPER EMPTY ARRAY ON EMPTY)`, sql)
+}
+
+func TestQuoteString(t *testing.T) {
+	assert.Equal(t, `"$.a"`, QuoteString("$.a"))
+	assert.Equal(t, `"a\\b\"c\n\x01"`, QuoteString("a\\b\"c\n\x01"))
+}
+
+func TestUnicodeDateTimeToSql(t *testing.T) {
+	sql, _, err := UnicodeToLower("name").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "Unicode::ToLower(name)", sql)
+
+	sql, _, err = DateTimeMakeDate(DateTimeStartOfMonth("created_at")).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DateTime::MakeDate(DateTime::StartOfMonth(created_at))", sql)
+
+	sql, _, err = DateTimeFormat("%Y-%m-%d", "created_at").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `DateTime::Format("%Y-%m-%d")(created_at)`, sql)
+}
+
+func TestFnWithBuilder(t *testing.T) {
+	b := yqb.Select("id").
+		Column(yqb.Alias(JSONValue("payload", "$.a"), "a")).
+		From("t").
+		Where(yqb.Eq{"status": Cast(Param("1"), types.TypeUint32)}).
+		Where(StartsWith("name", Param("Jo")))
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := `SELECT id, (JSON_VALUE(payload, "$.a")) AS a FROM t ` +
+		`WHERE status = CAST($p1 AS Uint32) AND StartsWith(name, $p2)`
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{types.TextValue("1"), types.TextValue("Jo")}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestFnWithNestedBuilder(t *testing.T) {
+	name := yqb.Select("name").From("users").Where(yqb.Eq{"id": 1})
+	b := yqb.Select("id").
+		Column(yqb.Alias(Coalesce(name, Param("unknown")), "name")).
+		From("orders").
+		Where(yqb.Eq{"status": 2})
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id, (COALESCE((SELECT name FROM users WHERE id = $p1), $p2)) AS name " +
+		"FROM orders WHERE status = $p3"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{types.Int64Value(1), types.TextValue("unknown"), types.Int64Value(2)}
+	assert.Equal(t, expectedArgs, args)
+}
@@ -0,0 +1,270 @@
+package fn
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// JSONBehavior is a behavior of JSON functions ON EMPTY or ON ERROR.
+type JSONBehavior struct {
+	sql      string
+	value    any
+	hasValue bool
+}
+
+var (
+	// JSONError raises an error.
+	JSONError = JSONBehavior{sql: "ERROR"}
+	// JSONNull returns NULL.
+	JSONNull = JSONBehavior{sql: "NULL"}
+	// JSONTrue returns true, JSON_EXISTS only.
+	JSONTrue = JSONBehavior{sql: "TRUE"}
+	// JSONFalse returns false, JSON_EXISTS only.
+	JSONFalse = JSONBehavior{sql: "FALSE"}
+	// JSONUnknown returns NULL, JSON_EXISTS only.
+	JSONUnknown = JSONBehavior{sql: "UNKNOWN"}
+	// JSONEmptyArray returns an empty array, JSON_QUERY only.
+	JSONEmptyArray = JSONBehavior{sql: "EMPTY ARRAY"}
+	// JSONEmptyObject returns an empty object, JSON_QUERY only.
+	JSONEmptyObject = JSONBehavior{sql: "EMPTY OBJECT"}
+)
+
+// JSONDefault returns the given value, JSON_VALUE only.
+func JSONDefault(value any) JSONBehavior {
+	return JSONBehavior{sql: "DEFAULT", value: value, hasValue: true}
+}
+
+var jsonVariableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type jsonVariable struct {
+	name  string
+	value any
+}
+
+type jsonFunc struct {
+	name    string
+	json    any
+	path    string
+	passing []jsonVariable
+	// the clauses after the path, a later call replaces an earlier one,
+	// they are written in the order of the grammar
+	returning string
+	wrapper   string
+	onEmpty   *JSONBehavior
+	onError   *JSONBehavior
+}
+
+func (f jsonFunc) passingVariable(name string, value any) jsonFunc {
+	f.passing = append(append([]jsonVariable(nil), f.passing...), jsonVariable{name: name, value: value})
+	return f
+}
+
+func (f jsonFunc) ToSql() (sql string, args []any, err error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(f.name)
+	buf.WriteString("(")
+
+	jsonSql, jsonArgs, err := argToSql(f.json)
+	if err != nil {
+		return
+	}
+	buf.WriteString(jsonSql)
+	args = append(args, jsonArgs...)
+
+	buf.WriteString(", ")
+	buf.WriteString(QuoteString(f.path))
+
+	if len(f.passing) > 0 {
+		buf.WriteString(" PASSING ")
+		for i, v := range f.passing {
+			if !jsonVariableRegexp.MatchString(v.name) {
+				err = fmt.Errorf("invalid JSON path variable name %q", v.name)
+				return
+			}
+
+			var valueSql string
+			var valueArgs []any
+			valueSql, valueArgs, err = argToSql(v.value)
+			if err != nil {
+				return
+			}
+
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(valueSql)
+			buf.WriteString(" AS ")
+			buf.WriteString(v.name)
+			args = append(args, valueArgs...)
+		}
+	}
+
+	if len(f.returning) > 0 {
+		buf.WriteString(" RETURNING ")
+		buf.WriteString(f.returning)
+	}
+	if len(f.wrapper) > 0 {
+		buf.WriteString(" ")
+		buf.WriteString(f.wrapper)
+	}
+	if args, err = appendBehaviorToSql(buf, f.onEmpty, "ON EMPTY", args); err != nil {
+		return
+	}
+	if args, err = appendBehaviorToSql(buf, f.onError, "ON ERROR", args); err != nil {
+		return
+	}
+
+	buf.WriteString(")")
+
+	sql = buf.String()
+	return
+}
+
+func appendBehaviorToSql(buf *bytes.Buffer, b *JSONBehavior, on string, args []any) ([]any, error) {
+	if b == nil {
+		return args, nil
+	}
+
+	buf.WriteString(" ")
+	buf.WriteString(b.sql)
+	if b.hasValue {
+		valueSql, valueArgs, err := argToSql(b.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(" ")
+		buf.WriteString(valueSql)
+		args = append(args, valueArgs...)
+	}
+	buf.WriteString(" ")
+	buf.WriteString(on)
+	return args, nil
+}
+
+// JSONValueFunc is a JSON_VALUE function call.
+type JSONValueFunc struct {
+	f jsonFunc
+}
+
+// JSONValue returns a JSON_VALUE function call, the path is quoted as a string literal.
+// Ex:
+//
+//	JSONValue("payload", "$.a").Returning(types.TypeUint32) == `JSON_VALUE(payload, "$.a" RETURNING Uint32)`
+func JSONValue(json any, path string) JSONValueFunc {
+	return JSONValueFunc{f: jsonFunc{name: "JSON_VALUE", json: json, path: path}}
+}
+
+// Passing adds a variable that can be used in the path as $name.
+func (j JSONValueFunc) Passing(name string, value any) JSONValueFunc {
+	return JSONValueFunc{f: j.f.passingVariable(name, value)}
+}
+
+// Returning sets the type of the result, it replaces the earlier one.
+func (j JSONValueFunc) Returning(t types.Type) JSONValueFunc {
+	j.f.returning = t.Yql()
+	return j
+}
+
+// OnEmpty sets the behavior when the path returns nothing, it replaces the earlier one.
+func (j JSONValueFunc) OnEmpty(b JSONBehavior) JSONValueFunc {
+	j.f.onEmpty = &b
+	return j
+}
+
+// OnError sets the behavior on errors, it replaces the earlier one.
+func (j JSONValueFunc) OnError(b JSONBehavior) JSONValueFunc {
+	j.f.onError = &b
+	return j
+}
+
+// ToSql implements yqb.Sqlizer.
+func (j JSONValueFunc) ToSql() (string, []any, error) {
+	return j.f.ToSql()
+}
+
+// JSONExistsFunc is a JSON_EXISTS function call.
+type JSONExistsFunc struct {
+	f jsonFunc
+}
+
+// JSONExists returns a JSON_EXISTS function call, the path is quoted as a string literal.
+// Ex:
+//
+//	JSONExists("payload", "$.a") == `JSON_EXISTS(payload, "$.a")`
+func JSONExists(json any, path string) JSONExistsFunc {
+	return JSONExistsFunc{f: jsonFunc{name: "JSON_EXISTS", json: json, path: path}}
+}
+
+// Passing adds a variable that can be used in the path as $name.
+func (j JSONExistsFunc) Passing(name string, value any) JSONExistsFunc {
+	return JSONExistsFunc{f: j.f.passingVariable(name, value)}
+}
+
+// OnError sets the behavior on errors, it replaces the earlier one.
+func (j JSONExistsFunc) OnError(b JSONBehavior) JSONExistsFunc {
+	j.f.onError = &b
+	return j
+}
+
+// ToSql implements yqb.Sqlizer.
+func (j JSONExistsFunc) ToSql() (string, []any, error) {
+	return j.f.ToSql()
+}
+
+// JSONQueryFunc is a JSON_QUERY function call.
+type JSONQueryFunc struct {
+	f jsonFunc
+}
+
+// JSONQuery returns a JSON_QUERY function call, the path is quoted as a string literal.
+// Ex:
+//
+//	JSONQuery("payload", "$.items").WithWrapper() == `JSON_QUERY(payload, "$.items" WITH WRAPPER)`
+func JSONQuery(json any, path string) JSONQueryFunc {
+	return JSONQueryFunc{f: jsonFunc{name: "JSON_QUERY", json: json, path: path}}
+}
+
+// Passing adds a variable that can be used in the path as $name.
+func (j JSONQueryFunc) Passing(name string, value any) JSONQueryFunc {
+	return JSONQueryFunc{f: j.f.passingVariable(name, value)}
+}
+
+// WithWrapper wraps the result into an array.
+func (j JSONQueryFunc) WithWrapper() JSONQueryFunc {
+	j.f.wrapper = "WITH WRAPPER"
+	return j
+}
+
+// WithConditionalWrapper wraps the result into an array if it isn't an array or an object.
+func (j JSONQueryFunc) WithConditionalWrapper() JSONQueryFunc {
+	j.f.wrapper = "WITH CONDITIONAL WRAPPER"
+	return j
+}
+
+// OnEmpty sets the behavior when the path returns nothing, it replaces the earlier one.
+func (j JSONQueryFunc) OnEmpty(b JSONBehavior) JSONQueryFunc {
+	j.f.onEmpty = &b
+	return j
+}
+
+// OnError sets the behavior on errors, it replaces the earlier one.
+func (j JSONQueryFunc) OnError(b JSONBehavior) JSONQueryFunc {
+	j.f.onError = &b
+	return j
+}
+
+// ToSql implements yqb.Sqlizer.
+func (j JSONQueryFunc) ToSql() (string, []any, error) {
+	return j.f.ToSql()
+}
+
+// QuoteString quotes s as a YQL string literal.
+//
+// See yqb.QuoteString.
+func QuoteString(s string) string {
+	return yqb.QuoteString(s)
+}
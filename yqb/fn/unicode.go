@@ -0,0 +1,39 @@
+package fn
+
+// Unicode returns a call of the Unicode UDF with the given name.
+// Ex:
+//
+//	Unicode("Reverse", "name") == "Unicode::Reverse(name)"
+func Unicode(name string, args ...any) Func {
+	return Call("Unicode::"+name, args...)
+}
+
+// UnicodeToLower returns a Unicode::ToLower function call.
+func UnicodeToLower(expr any) Func {
+	return Unicode("ToLower", expr)
+}
+
+// UnicodeToUpper returns a Unicode::ToUpper function call.
+func UnicodeToUpper(expr any) Func {
+	return Unicode("ToUpper", expr)
+}
+
+// UnicodeGetLength returns a Unicode::GetLength function call.
+func UnicodeGetLength(expr any) Func {
+	return Unicode("GetLength", expr)
+}
+
+// UnicodeStrip returns a Unicode::Strip function call.
+func UnicodeStrip(expr any) Func {
+	return Unicode("Strip", expr)
+}
+
+// UnicodeSubstring returns a Unicode::Substring function call.
+func UnicodeSubstring(expr, start, length any) Func {
+	return Unicode("Substring", expr, start, length)
+}
+
+// UnicodeContains returns a Unicode::Contains function call.
+func UnicodeContains(expr, substring any) Func {
+	return Unicode("Contains", expr, substring)
+}
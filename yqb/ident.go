@@ -0,0 +1,275 @@
+package yqb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidIdent is returned when an identifier isn't valid for the IdentMode of the builder.
+var ErrInvalidIdent = errors.New("invalid identifier")
+
+// IdentMode defines how builders treat table and column names.
+type IdentMode int
+
+const (
+	// IdentAsIs writes identifiers into the query as is.
+	IdentAsIs IdentMode = iota
+	// IdentQuote quotes every identifier with backticks, unless it's already quoted.
+	// Names separated by dots (e.g. t.id) are quoted part by part.
+	IdentQuote
+	// IdentValidate returns ErrInvalidIdent for identifiers that aren't plain
+	// names (e.g. id, t.id) or quoted with backticks (see Ident and Path).
+	IdentValidate
+)
+
+// Ident quotes a name with backticks, several names are joined with dots.
+// Ex:
+//
+//	Ident("users") == "`users`"
+//	Ident("u", "created-at") == "`u`.`created-at`"
+func Ident(names ...string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, quoteIdent(name))
+	}
+	return strings.Join(quoted, ".")
+}
+
+// Path quotes a table path with backticks, several parts are joined with slashes.
+// Ex:
+//
+//	Path("auth", "users") == "`auth/users`"
+func Path(parts ...string) string {
+	return quoteIdent(strings.Join(parts, "/"))
+}
+
+func quoteIdent(name string) string {
+	var sb strings.Builder
+	sb.WriteByte('`')
+	for i := 0; i < len(name); i++ {
+		if name[i] == '`' || name[i] == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(name[i])
+	}
+	sb.WriteByte('`')
+	return sb.String()
+}
+
+// apply quotes or validates the identifier according to the mode.
+func (m IdentMode) apply(name string) (string, error) {
+	switch m {
+	case IdentQuote:
+		parts, ok := splitIdent(name)
+		if !ok {
+			return quoteIdent(name), nil
+		}
+		for i, part := range parts {
+			if !strings.HasPrefix(part, "`") {
+				parts[i] = quoteIdent(part)
+			}
+		}
+		return strings.Join(parts, "."), nil
+	case IdentValidate:
+		if _, ok := splitIdent(name); !ok {
+			return "", fmt.Errorf("%w: %q", ErrInvalidIdent, name)
+		}
+		return name, nil
+	default:
+		return name, nil
+	}
+}
+
+// applyAll applies the mode to every identifier.
+func (m IdentMode) applyAll(names []string) ([]string, error) {
+	if m == IdentAsIs {
+		return names, nil
+	}
+	applied := make([]string, 0, len(names))
+	for _, name := range names {
+		n, err := m.apply(name)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, n)
+	}
+	return applied, nil
+}
+
+// applyColumns applies the mode to every column name, * is left as is.
+func (m IdentMode) applyColumns(columns []string) ([]string, error) {
+	if m == IdentAsIs {
+		return columns, nil
+	}
+	applied := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column == "*" {
+			applied = append(applied, column)
+			continue
+		}
+		c, err := m.apply(column)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, c)
+	}
+	return applied, nil
+}
+
+// applyOrders applies the mode to the column of every ORDER BY expression,
+// the ASC or DESC suffix is left as is, e.g. "created_at DESC".
+func (m IdentMode) applyOrders(orderBys []string) ([]string, error) {
+	if m == IdentAsIs {
+		return orderBys, nil
+	}
+	applied := make([]string, 0, len(orderBys))
+	for _, orderBy := range orderBys {
+		column, direction := strings.TrimSpace(orderBy), ""
+		if i := strings.LastIndexAny(column, " \t\n"); i >= 0 {
+			switch strings.ToUpper(column[i+1:]) {
+			case "ASC", "DESC":
+				column, direction = strings.TrimSpace(column[:i]), " "+column[i+1:]
+			}
+		}
+		c, err := m.apply(column)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, c+direction)
+	}
+	return applied, nil
+}
+
+// applyKeys applies the mode to every key of the predicate map.
+func (m IdentMode) applyKeys(pred map[string]any) (map[string]any, error) {
+	if m == IdentAsIs {
+		return pred, nil
+	}
+	applied := make(map[string]any, len(pred))
+	for key, val := range pred {
+		k, err := m.apply(key)
+		if err != nil {
+			return nil, err
+		}
+		applied[k] = val
+	}
+	return applied, nil
+}
+
+// splitIdent splits a dot separated identifier into plain or quoted names.
+// It returns false if the identifier contains anything else.
+func splitIdent(name string) ([]string, bool) {
+	var parts []string
+	for len(name) > 0 {
+		var n int
+		if name[0] == '`' {
+			n = quotedIdentLen(name)
+		} else {
+			n = plainIdentLen(name)
+		}
+		if n == 0 {
+			return nil, false
+		}
+		parts = append(parts, name[:n])
+		name = name[n:]
+
+		if len(name) == 0 {
+			return parts, true
+		}
+		if name[0] != '.' || len(name) == 1 {
+			return nil, false
+		}
+		name = name[1:]
+	}
+	return nil, false
+}
+
+func plainIdentLen(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 0:
+		default:
+			return i
+		}
+	}
+	return len(s)
+}
+
+func quotedIdentLen(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			if i == 1 {
+				return 0
+			}
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// applyPart applies the mode to a part built from a string, e.g. by From.
+func (m IdentMode) applyPart(s Sqlizer) (Sqlizer, error) {
+	p, ok := s.(*part)
+	if !ok || m == IdentAsIs {
+		return s, nil
+	}
+	name, ok := p.pred.(string)
+	if !ok {
+		return s, nil
+	}
+	applied, err := m.apply(name)
+	if err != nil {
+		return nil, err
+	}
+	return newPart(applied, p.args...), nil
+}
+
+// identList is a list of identifiers, e.g. the columns of PRIMARY KEY,
+// the IdentMode of the builder is applied to them, see applyList.
+type identList []string
+
+func (l identList) ToSql() (string, []any, error) {
+	return strings.Join(l, ", "), nil, nil
+}
+
+// orderByColumn is an ORDER BY expression added by OrderBy,
+// the IdentMode of the builder is applied to its column, see applyList.
+type orderByColumn string
+
+func (c orderByColumn) ToSql() (string, []any, error) {
+	return string(c), nil, nil
+}
+
+// applyList applies the mode to the identifiers of identList and orderByColumn parts,
+// other parts are left as is.
+func (m IdentMode) applyList(parts []Sqlizer) ([]Sqlizer, error) {
+	if m == IdentAsIs {
+		return parts, nil
+	}
+	applied := make([]Sqlizer, 0, len(parts))
+	for _, p := range parts {
+		switch p := p.(type) {
+		case identList:
+			names, err := m.applyAll(p)
+			if err != nil {
+				return nil, err
+			}
+			applied = append(applied, identList(names))
+		case orderByColumn:
+			orderBys, err := m.applyOrders([]string{string(p)})
+			if err != nil {
+				return nil, err
+			}
+			applied = append(applied, orderByColumn(orderBys[0]))
+		default:
+			applied = append(applied, p)
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,107 @@
+package yqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdent(t *testing.T) {
+	assert.Equal(t, "`users`", Ident("users"))
+	assert.Equal(t, "`u`.`created-at`", Ident("u", "created-at"))
+	assert.Equal(t, "`a\\`b`", Ident("a`b"))
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "`auth/users`", Path("auth", "users"))
+	assert.Equal(t, "`/local/auth/users`", Path("/local/auth/users"))
+}
+
+func TestIdentModeQuote(t *testing.T) {
+	sb := StatementBuilder.IdentMode(IdentQuote)
+
+	sql, _, err := sb.Select("id").
+		From("auth/users").
+		Where(Eq{"u.status": 1, "`group`": 2}).
+		Where(Or{Gt{"created-at": 3}, Like{"name": "a%"}}).
+		Where("x = ?", 4).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT id FROM `auth/users` " +
+		"WHERE `group` = $p1 AND `u`.`status` = $p2 AND (`created-at` > $p3 OR `name` LIKE $p4) AND x = $p5"
+	assert.Equal(t, expectedSql, sql)
+
+	sql, _, err = sb.Insert("users").Columns("id", "user-name").Values(1, "a").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `users` (`id`,`user-name`) VALUES ($p1,$p2)", sql)
+
+	sql, _, err = sb.Update("users").Set("user-name", "a").Where(Eq{"id": 1}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `users` SET `user-name` = $p1 WHERE `id` = $p2", sql)
+
+	sql, _, err = sb.Delete("users").Where(Eq{"id": 1}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `users` WHERE `id` = $p1", sql)
+
+	sql, _, err = sb.Drop("users").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP TABLE `users`", sql)
+
+	sql, _, err = sb.Create("users").Columns("id", "name").Types("Uint64", "Utf8").PrimaryKey("id, name").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE `users` ( `id` Uint64, `name` Utf8, PRIMARY KEY (`id`, `name`) )", sql)
+
+	sql, _, err = sb.Select("company", "COUNT(*)").
+		From("users").
+		GroupBy("company").
+		OrderBy("company DESC", "u.created_at").
+		OrderByClause("COUNT(*) DESC").
+		ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT company, COUNT(*) FROM `users` GROUP BY `company` "+
+		"ORDER BY `company` DESC, `u`.`created_at`, COUNT(*) DESC", sql)
+
+	sql, _, err = sb.Delete("users").Where(Eq{"id": 1}).OrderBy("id asc").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM `users` WHERE `id` = $p1 ORDER BY `id` asc", sql)
+}
+
+func TestIdentModeValidate(t *testing.T) {
+	sb := StatementBuilder.IdentMode(IdentValidate)
+
+	sql, _, err := sb.Select("id").From(Path("auth", "users")).Where(Eq{"u.id": 1, "`a-b`": 2}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM `auth/users` WHERE `a-b` = $p1 AND u.id = $p2", sql)
+
+	_, _, err = sb.Select("id").From("users").Where(Eq{"id; DROP TABLE users": 1}).ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Select("id").From("auth/users").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Insert("users").Columns("id", "a b").Values(1, 2).ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Update("users").Set("a.", 1).AllRows().ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Create("users").Columns("1id").Types("Uint64").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Create("users").Columns("id").Types("Uint64").PrimaryKey("id) ) --").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	sql, _, err = sb.Select("id").From("users").OrderBy("id DESC").GroupBy("id").ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users GROUP BY id ORDER BY id DESC", sql)
+
+	_, _, err = sb.Select("id").From("users").OrderBy("(SELECT 1) DESC").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Select("id").From("users").GroupBy("id; DROP TABLE users").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+
+	_, _, err = sb.Update("users").Set("a", 1).AllRows().OrderBy("a, b").ToSql()
+	assert.ErrorIs(t, err, ErrInvalidIdent)
+}
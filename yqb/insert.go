@@ -9,21 +9,21 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
 type insertData struct {
-	PlaceholderFormat PlaceholderFormat
-	RunWith           BaseRunner
-	Prefixes          []Sqlizer
-	StatementKeyword  string
-	Options           []string
-	Into              string
-	Columns           []string
-	Values            [][]any
-	Suffixes          []Sqlizer
-	Select            *SelectBuilder
+	statementOptions
+
+	Prefixes         []Sqlizer
+	StatementKeyword string
+	Options          []string
+	Into             string
+	Columns          []string
+	Values           [][]any
+	Returning        []string
+	Suffixes         []Sqlizer
+	Select           *SelectBuilder
 }
 
 func (d *insertData) Exec() (sql.Result, error) {
@@ -103,13 +103,24 @@ func (d *insertData) toSqlRaw() (sqlStr string, args []any, err error) {
 		sql.WriteString(" ")
 	}
 
+	into, err := d.IdentMode.apply(d.Into)
+	if err != nil {
+		return
+	}
+
 	sql.WriteString("INTO ")
-	sql.WriteString(d.Into)
+	sql.WriteString(into)
 	sql.WriteString(" ")
 
 	if len(d.Columns) > 0 {
+		var columns []string
+		columns, err = d.IdentMode.applyAll(d.Columns)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString("(")
-		sql.WriteString(strings.Join(d.Columns, ","))
+		sql.WriteString(strings.Join(columns, ","))
 		sql.WriteString(") ")
 	}
 
@@ -122,6 +133,16 @@ func (d *insertData) toSqlRaw() (sqlStr string, args []any, err error) {
 		return
 	}
 
+	if len(d.Returning) > 0 {
+		var returning []string
+		returning, err = d.IdentMode.applyColumns(d.Returning)
+		if err != nil {
+			return
+		}
+		sql.WriteString(" RETURNING ")
+		sql.WriteString(strings.Join(returning, ", "))
+	}
+
 	if len(d.Suffixes) > 0 {
 		sql.WriteString(" ")
 		args, err = appendToSql(d.Suffixes, sql, " ", args)
@@ -185,10 +206,12 @@ func (d *insertData) appendSelectToSQL(w io.Writer, args []any) ([]any, error) {
 // Builder
 
 // InsertBuilder builds SQL INSERT statements.
-type InsertBuilder builder.Builder
+type InsertBuilder struct {
+	d *insertData
+}
 
-func init() {
-	builder.Register(InsertBuilder{}, insertData{})
+func (b InsertBuilder) data() *insertData {
+	return dataOf(b.d)
 }
 
 // Format methods
@@ -196,31 +219,63 @@ func init() {
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b InsertBuilder) PlaceholderFormat(f PlaceholderFormat) InsertBuilder {
-	return builder.Set(b, "PlaceholderFormat", f).(InsertBuilder)
+	d := clone(b.d)
+	d.PlaceholderFormat = f
+	return InsertBuilder{d}
+}
+
+// IdentMode sets IdentMode (e.g. IdentQuote or IdentValidate) for table and
+// column names of the query.
+//
+// See StatementBuilderType.IdentMode.
+func (b InsertBuilder) IdentMode(mode IdentMode) InsertBuilder {
+	d := clone(b.d)
+	d.IdentMode = mode
+	return InsertBuilder{d}
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b InsertBuilder) OmitDeclare(omit bool) InsertBuilder {
+	d := clone(b.d)
+	d.OmitDeclare = omit
+	return InsertBuilder{d}
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b InsertBuilder) Limits(limits Limits) InsertBuilder {
+	d := clone(b.d)
+	d.Limits = limits
+	return InsertBuilder{d}
 }
 
 // Runner methods
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b InsertBuilder) RunWith(runner BaseRunner) InsertBuilder {
-	return setRunWith(b, runner).(InsertBuilder)
+	d := clone(b.d)
+	d.RunWith = wrapRunner(runner)
+	return InsertBuilder{d}
 }
 
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b InsertBuilder) Exec() (sql.Result, error) {
-	data := builder.GetStruct(b).(insertData)
+	data := b.data()
 	return data.Exec()
 }
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b InsertBuilder) Query() (*sql.Rows, error) {
-	data := builder.GetStruct(b).(insertData)
+	data := b.data()
 	return data.Query()
 }
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
 func (b InsertBuilder) QueryRow() RowScanner {
-	data := builder.GetStruct(b).(insertData)
+	data := b.data()
 	return data.QueryRow()
 }
 
@@ -233,7 +288,7 @@ func (b InsertBuilder) Scan(dest ...any) error {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b InsertBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(insertData)
+	data := b.data()
 	return data.ToSql()
 }
 
@@ -244,7 +299,7 @@ func (b InsertBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
 	}
 
-	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args)
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, b.data().OmitDeclare)
 	if err != nil {
 		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
 	}
@@ -254,6 +309,10 @@ func (b InsertBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
 	}
 
+	if err = checkLimits(b.data().Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
 	return ydbSqlStr, ydbArgs, err
 }
 
@@ -264,27 +323,106 @@ func (b InsertBuilder) Prefix(sql string, args ...any) InsertBuilder {
 
 // PrefixExpr adds an expression to the very beginning of the query
 func (b InsertBuilder) PrefixExpr(expr Sqlizer) InsertBuilder {
-	return builder.Append(b, "Prefixes", expr).(InsertBuilder)
+	d := clone(b.d)
+	d.Prefixes = appendCopy(d.Prefixes, expr)
+	return InsertBuilder{d}
 }
 
 // Options adds keyword options before the INTO clause of the query.
 func (b InsertBuilder) Options(options ...string) InsertBuilder {
-	return builder.Extend(b, "Options", options).(InsertBuilder)
+	d := clone(b.d)
+	d.Options = appendCopy(d.Options, options...)
+	return InsertBuilder{d}
 }
 
 // Into sets the INTO clause of the query.
 func (b InsertBuilder) Into(from string) InsertBuilder {
-	return builder.Set(b, "Into", from).(InsertBuilder)
+	d := clone(b.d)
+	d.Into = from
+	return InsertBuilder{d}
 }
 
 // Columns adds insert columns to the query.
 func (b InsertBuilder) Columns(columns ...string) InsertBuilder {
-	return builder.Extend(b, "Columns", columns).(InsertBuilder)
+	d := clone(b.d)
+	d.Columns = appendCopy(d.Columns, columns...)
+	return InsertBuilder{d}
 }
 
 // Values adds a single row's values to the query.
 func (b InsertBuilder) Values(values ...any) InsertBuilder {
-	return builder.Append(b, "Values", values).(InsertBuilder)
+	d := clone(b.d)
+	d.Values = appendCopy(d.Values, values)
+	return InsertBuilder{d}
+}
+
+// Chunks splits the rows set by Values into several builders with at most
+// maxRows rows each, maxRows <= 0 means no limit of the rows.
+// Chunks that exceed Limits are split further, a row that exceeds Limits
+// on its own returns *LimitError.
+// Ex:
+//
+//	chunks, err := StatementBuilder.Limits(Limits{MaxParams: 1000}).
+//		Insert("users").Columns("id", "name").Values(...).Chunks(100)
+func (b InsertBuilder) Chunks(maxRows int) ([]InsertBuilder, error) {
+	values := b.data().Values
+	if maxRows <= 0 || maxRows > len(values) {
+		maxRows = len(values)
+	}
+
+	var chunks []InsertBuilder
+	for start := 0; start < len(values); start += maxRows {
+		end := start + maxRows
+		if end > len(values) {
+			end = len(values)
+		}
+
+		var err error
+		chunks, err = b.appendChunks(chunks, values[start:end:end])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chunks, nil
+}
+
+// appendChunks appends the builders with the rows, halving the rows until the
+// query fits in Limits.
+func (b InsertBuilder) appendChunks(chunks []InsertBuilder, rows [][]any) ([]InsertBuilder, error) {
+	d := clone(b.d)
+	d.Values = rows
+	chunk := InsertBuilder{d}
+
+	if d.Limits == (Limits{}) {
+		return append(chunks, chunk), nil
+	}
+
+	_, _, err := chunk.ToYdbSql()
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) && len(rows) > 1 {
+		half := len(rows) / 2
+		chunks, err = b.appendChunks(chunks, rows[:half:half])
+		if err != nil {
+			return nil, err
+		}
+		return b.appendChunks(chunks, rows[half:])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(chunks, chunk), nil
+}
+
+// Returning adds a RETURNING clause to the query, it returns the inserted rows.
+// Use * to return all columns.
+// The rows can be scanned with GetX and SelectX of yqe.Executor.
+// Ex:
+//
+//	Insert("users").Columns("name").Values("a").Returning("id", "created_at")
+func (b InsertBuilder) Returning(columns ...string) InsertBuilder {
+	d := clone(b.d)
+	d.Returning = appendCopy(d.Returning, columns...)
+	return InsertBuilder{d}
 }
 
 // Suffix adds an expression to the end of the query
@@ -294,7 +432,9 @@ func (b InsertBuilder) Suffix(sql string, args ...any) InsertBuilder {
 
 // SuffixExpr adds an expression to the end of the query
 func (b InsertBuilder) SuffixExpr(expr Sqlizer) InsertBuilder {
-	return builder.Append(b, "Suffixes", expr).(InsertBuilder)
+	d := clone(b.d)
+	d.Suffixes = appendCopy(d.Suffixes, expr)
+	return InsertBuilder{d}
 }
 
 // SetMap set columns and values for insert builder from a map of column name and value
@@ -312,18 +452,22 @@ func (b InsertBuilder) SetMap(clauses map[string]any) InsertBuilder {
 		vals = append(vals, clauses[col])
 	}
 
-	b = builder.Set(b, "Columns", cols).(InsertBuilder)
-	b = builder.Set(b, "Values", [][]any{vals}).(InsertBuilder)
-
-	return b
+	d := clone(b.d)
+	d.Columns = cols
+	d.Values = [][]any{vals}
+	return InsertBuilder{d}
 }
 
 // Select set Select clause for insert query
 // If Values and Select are used, then Select has higher priority
 func (b InsertBuilder) Select(sb SelectBuilder) InsertBuilder {
-	return builder.Set(b, "Select", &sb).(InsertBuilder)
+	d := clone(b.d)
+	d.Select = &sb
+	return InsertBuilder{d}
 }
 
 func (b InsertBuilder) statementKeyword(keyword string) InsertBuilder {
-	return builder.Set(b, "StatementKeyword", keyword).(InsertBuilder)
+	d := clone(b.d)
+	d.StatementKeyword = keyword
+	return InsertBuilder{d}
 }
@@ -118,3 +118,51 @@ func TestInsertBuilderReplace(t *testing.T) {
 
 	assert.Equal(t, expectedSQL, sql)
 }
+
+func TestInsertBuilderReturning(t *testing.T) {
+	b := Insert("table").Columns("name").Values("a").Returning("id", "created_at").Suffix("-- x")
+
+	sql, _, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSQL := "INSERT INTO table (name) VALUES ($p1) RETURNING id, created_at -- x"
+	assert.Equal(t, expectedSQL, sql)
+}
+
+func TestInsertBuilderChunks(t *testing.T) {
+	b := Insert("test").Columns("a", "b")
+	for i := 1; i <= 5; i++ {
+		b = b.Values(i, i*10)
+	}
+
+	chunks, err := b.Chunks(2)
+	assert.NoError(t, err)
+
+	expectedSqls := []string{
+		"INSERT INTO test (a,b) VALUES ($p1,$p2),($p3,$p4)",
+		"INSERT INTO test (a,b) VALUES ($p1,$p2),($p3,$p4)",
+		"INSERT INTO test (a,b) VALUES ($p1,$p2)",
+	}
+	if assert.Len(t, chunks, len(expectedSqls)) {
+		for i, chunk := range chunks {
+			sql, _, err := chunk.ToSql()
+			assert.NoError(t, err)
+			assert.Equal(t, expectedSqls[i], sql)
+		}
+		_, args, _ := chunks[2].ToSql()
+		assert.Equal(t, []any{types.Int64Value(5), types.Int64Value(50)}, args)
+	}
+
+	chunks, err = b.Limits(Limits{MaxParams: 4}).Chunks(0)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 3)
+	for _, chunk := range chunks {
+		_, args, err := chunk.ToYdbSql()
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(args), 4)
+	}
+
+	_, err = b.Limits(Limits{MaxParams: 1}).Chunks(0)
+	var limitErr *LimitError
+	assert.ErrorAs(t, err, &limitErr)
+}
@@ -0,0 +1,67 @@
+package yqb
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+// Limits are the limits of a query checked by ToYdbSql, zero values are not checked.
+//
+// YDB rejects queries above its limits only at execute time, set the limits
+// of your database to find out before the query is sent.
+type Limits struct {
+	// MaxQueryBytes is the max size of the query text with DECLAREs.
+	MaxQueryBytes int
+	// MaxParams is the max number of the params.
+	MaxParams int
+	// MaxParamBytes is the max total size of the param values.
+	// The size of a value is estimated by the length of its YQL literal.
+	MaxParamBytes int
+}
+
+// LimitKind is a kind of query limit, see Limits.
+type LimitKind string
+
+const (
+	// QueryBytesLimit is Limits.MaxQueryBytes.
+	QueryBytesLimit LimitKind = "query bytes"
+	// ParamsLimit is Limits.MaxParams.
+	ParamsLimit LimitKind = "params"
+	// ParamBytesLimit is Limits.MaxParamBytes.
+	ParamBytesLimit LimitKind = "param bytes"
+)
+
+// LimitError is returned by ToYdbSql if the query exceeds Limits.
+type LimitError struct {
+	// Kind is the exceeded limit.
+	Kind LimitKind
+	// Value is the value of the query, e.g. the number of the params.
+	Value int
+	// Max is the limit.
+	Max int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("query %s %d exceed the limit %d", e.Kind, e.Value, e.Max)
+}
+
+// checkLimits returns *LimitError if the query exceeds the limits.
+func checkLimits(limits Limits, query string, params []table.ParameterOption) error {
+	if limits.MaxQueryBytes > 0 && len(query) > limits.MaxQueryBytes {
+		return &LimitError{Kind: QueryBytesLimit, Value: len(query), Max: limits.MaxQueryBytes}
+	}
+	if limits.MaxParams > 0 && len(params) > limits.MaxParams {
+		return &LimitError{Kind: ParamsLimit, Value: len(params), Max: limits.MaxParams}
+	}
+	if limits.MaxParamBytes > 0 {
+		size := 0
+		for _, p := range params {
+			size += len(p.Value().Yql())
+		}
+		if size > limits.MaxParamBytes {
+			return &LimitError{Kind: ParamBytesLimit, Value: size, Max: limits.MaxParamBytes}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package yqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+	b := Select("id").From("users").Where(Eq{"id": 1, "name": "abc"})
+
+	_, _, err := b.Limits(Limits{MaxQueryBytes: 93, MaxParams: 2, MaxParamBytes: 8}).ToYdbSql()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		limits   Limits
+		expected LimitError
+	}{
+		{Limits{MaxQueryBytes: 90}, LimitError{Kind: QueryBytesLimit, Value: 93, Max: 90}},
+		{Limits{MaxParams: 1}, LimitError{Kind: ParamsLimit, Value: 2, Max: 1}},
+		{Limits{MaxParamBytes: 7}, LimitError{Kind: ParamBytesLimit, Value: 8, Max: 7}},
+	}
+	for _, tt := range tests {
+		_, _, err = b.Limits(tt.limits).ToYdbSql()
+
+		var limitErr *LimitError
+		if assert.True(t, errors.As(err, &limitErr)) {
+			assert.Equal(t, tt.expected, *limitErr)
+		}
+	}
+
+	_, _, err = StatementBuilder.Limits(Limits{MaxParams: 1}).Delete("users").Where(Eq{"id": 1, "v": 2}).ToYdbSql()
+	assert.EqualError(t, err, "checkLimits: query params 2 exceed the limit 1")
+}
@@ -0,0 +1,154 @@
+package yqb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+// MultiBuilder builds several statements into one query with shared params,
+// e.g. to read several result sets in one round trip.
+//
+// The statement options, e.g. OmitDeclare and Limits, are taken from the first query
+// if it's a builder of this package, the setters of MultiBuilder override them.
+type MultiBuilder struct {
+	queries []Sqlizer
+	options *statementOptions
+}
+
+// Multi returns a MultiBuilder of the queries, they are separated by semicolons.
+// Ex:
+//
+//	Multi(
+//		Select("*").From("users").Where(Eq{"id": 1}),
+//		Select("*").From("orders").Where(Eq{"user_id": 1}),
+//	)
+//	// SELECT * FROM users WHERE id = $p1;
+//	// SELECT * FROM orders WHERE user_id = $p2
+func Multi(queries ...Sqlizer) MultiBuilder {
+	return MultiBuilder{queries: queries}
+}
+
+// PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the query.
+func (b MultiBuilder) PlaceholderFormat(f PlaceholderFormat) MultiBuilder {
+	return b.withOptions(func(o *statementOptions) { o.PlaceholderFormat = f })
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b MultiBuilder) OmitDeclare(omit bool) MultiBuilder {
+	return b.withOptions(func(o *statementOptions) { o.OmitDeclare = omit })
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b MultiBuilder) Limits(limits Limits) MultiBuilder {
+	return b.withOptions(func(o *statementOptions) { o.Limits = limits })
+}
+
+// withOptions returns a copy of the builder with the changed statement options.
+func (b MultiBuilder) withOptions(f func(o *statementOptions)) MultiBuilder {
+	o := b.statementOptions()
+	f(&o)
+	return MultiBuilder{queries: b.queries, options: &o}
+}
+
+// statementOptions returns the options set on the builder or the options of the first query.
+func (b MultiBuilder) statementOptions() statementOptions {
+	if b.options != nil {
+		return *b.options
+	}
+	if len(b.queries) > 0 {
+		if o, ok := statementOptionsOf(b.queries[0]); ok {
+			return o
+		}
+	}
+	return StatementBuilder.options
+}
+
+// statementOptionsOf returns the statement options of the builders of this package.
+func statementOptionsOf(q Sqlizer) (statementOptions, bool) {
+	switch q := q.(type) {
+	case SelectBuilder:
+		return q.data().statementOptions, true
+	case InsertBuilder:
+		return q.data().statementOptions, true
+	case UpdateBuilder:
+		return q.data().statementOptions, true
+	case DeleteBuilder:
+		return q.data().statementOptions, true
+	case CreateBuilder:
+		return q.data().statementOptions, true
+	case DropBuilder:
+		return q.data().statementOptions, true
+	case MultiBuilder:
+		return q.statementOptions(), true
+	}
+	return statementOptions{}, false
+}
+
+func (b MultiBuilder) toSqlRaw() (sqlStr string, args []any, err error) {
+	if len(b.queries) == 0 {
+		return "", nil, fmt.Errorf("multi statements must have at least one query")
+	}
+
+	sqls := make([]string, 0, len(b.queries))
+	for _, q := range b.queries {
+		qSql, qArgs, err := nestedToSql(q)
+		if err != nil {
+			return "", nil, err
+		}
+		sqls = append(sqls, qSql)
+		args = append(args, qArgs...)
+	}
+
+	return strings.Join(sqls, ";\n"), args, nil
+}
+
+// ToSql builds the query into a SQL string and bound args.
+func (b MultiBuilder) ToSql() (sqlStr string, args []any, err error) {
+	sqlStr, args, err = b.toSqlRaw()
+	if err != nil {
+		return
+	}
+
+	placeholderFormat := b.statementOptions().PlaceholderFormat
+	if placeholderFormat == nil {
+		placeholderFormat = DollarP
+	}
+	sqlStr, err = placeholderFormat.ReplacePlaceholders(sqlStr)
+	if err != nil {
+		return
+	}
+
+	args, err = castArgsToYdb(args)
+	return
+}
+
+// ToYdbSql builds the query into a SQL string and bound args.
+func (b MultiBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
+	sqlStr, args, err := b.ToSql()
+	if err != nil {
+		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
+	}
+
+	o := b.statementOptions()
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, o.OmitDeclare)
+	if err != nil {
+		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
+	}
+
+	ydbArgs, err := prepareYdbParams(args)
+	if err != nil {
+		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
+	}
+
+	if err = checkLimits(o.Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
+	return ydbSqlStr, ydbArgs, err
+}
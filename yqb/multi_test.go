@@ -0,0 +1,89 @@
+package yqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestMultiBuilder(t *testing.T) {
+	b := Multi(
+		Select("*").From("users").Where(Eq{"id": 1}),
+		Select("*").From("orders").Where(Eq{"user_id": 1}).Limit(10),
+		Expr("SELECT ?", "x"),
+	)
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT * FROM users WHERE id = $p1;\n" +
+		"SELECT * FROM orders WHERE user_id = $p2 LIMIT 10;\n" +
+		"SELECT $p3"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{types.Int64Value(1), types.Int64Value(1), types.UTF8Value("x")}
+	assert.Equal(t, expectedArgs, args)
+
+	ydbSql, params, err := b.ToYdbSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS Int64;\nDECLARE $p2 AS Int64;\nDECLARE $p3 AS Utf8;\n"+expectedSql, ydbSql)
+	assert.Len(t, params, 3)
+
+	_, _, err = Multi().ToSql()
+	assert.Error(t, err)
+
+	_, _, err = Multi(Select("id").From("users"), Delete("users")).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+}
+
+func TestMultiBuilderOptions(t *testing.T) {
+	sb := StatementBuilder.OmitDeclare(true).Limits(Limits{MaxParams: 1})
+	b := Multi(
+		sb.Select("*").From("users").Where(Eq{"id": 1}),
+		Select("*").From("orders").Where(Eq{"user_id": 1}),
+	)
+
+	_, _, err := b.ToYdbSql()
+	var limitErr *LimitError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, ParamsLimit, limitErr.Kind)
+
+	ydbSql, params, err := b.Limits(Limits{}).ToYdbSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = $p1;\nSELECT * FROM orders WHERE user_id = $p2", ydbSql)
+	assert.Len(t, params, 2)
+
+	ydbSql, _, err = b.Limits(Limits{}).OmitDeclare(false).ToYdbSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS Int64;\nDECLARE $p2 AS Int64;\n"+
+		"SELECT * FROM users WHERE id = $p1;\nSELECT * FROM orders WHERE user_id = $p2", ydbSql)
+
+	sql, _, err := b.PlaceholderFormat(Question).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ?;\nSELECT * FROM orders WHERE user_id = ?", sql)
+}
+
+func TestDescribeMulti(t *testing.T) {
+	info, err := Describe(Multi(
+		Select("*").From("users").Where(Eq{"id": 1}),
+		Select("*").From("orders").Join("users u ON u.id = orders.user_id"),
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementRead, info.Kind)
+	assert.Equal(t, []string{"users", "orders"}, info.ReadTables)
+
+	info, err = Describe(Multi(
+		Select("*").From("users"),
+		Expr("UPSERT INTO logs (id) VALUES (?)", 1),
+		Delete("orders").Where(Eq{"id": 1}),
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementWrite, info.Kind)
+	assert.Equal(t, []string{"users"}, info.ReadTables)
+	assert.Equal(t, []string{"orders"}, info.WriteTables)
+
+	info, err = Describe(Multi(Select("*").From("users"), Expr("$x = 1")))
+	assert.NoError(t, err)
+	assert.Equal(t, StatementUnknown, info.Kind)
+}
@@ -29,6 +29,13 @@ func (p part) ToSql() (sql string, args []any, err error) {
 	return
 }
 
+// NestedToSql builds s as a part of another query: the builders of this package
+// leave their placeholders as ? to be numbered by the outer query.
+// It's for Sqlizers of other packages with nested builders, e.g. yqb/fn.
+func NestedToSql(s Sqlizer) (string, []any, error) {
+	return nestedToSql(s)
+}
+
 func nestedToSql(s Sqlizer) (string, []any, error) {
 	if raw, ok := s.(rawSqlizer); ok {
 		return raw.toSqlRaw()
@@ -61,3 +68,25 @@ func appendToSql(parts []Sqlizer, w io.Writer, sep string, args []any) ([]any, e
 	}
 	return args, nil
 }
+
+// limitValue is a number of LIMIT or OFFSET clauses.
+type limitValue uint64
+
+func (v limitValue) ToSql() (string, []any, error) {
+	return fmt.Sprintf("%d", uint64(v)), nil, nil
+}
+
+// appendLimitToSql writes the LIMIT or OFFSET clause, numbers are bound as
+// Uint64 parameters if params is true.
+func appendLimitToSql(keyword string, limit Sqlizer, params bool, w io.Writer, args []any) ([]any, error) {
+	if _, err := io.WriteString(w, keyword); err != nil {
+		return nil, err
+	}
+	if v, ok := limit.(limitValue); ok && params {
+		if _, err := io.WriteString(w, "?"); err != nil {
+			return nil, err
+		}
+		return append(args, uint64(v)), nil
+	}
+	return appendToSql([]Sqlizer{limit}, w, "", args)
+}
@@ -95,25 +95,48 @@ func Placeholders(count int) string {
 }
 
 func replacePositionalPlaceholders(sql, prefix string) (string, error) {
+	return replacePlaceholders(sql, func(i int) (string, error) {
+		return fmt.Sprintf("%s%d", prefix, i), nil
+	})
+}
+
+// replacePlaceholders replaces each question mark placeholder with the result
+// of replace called with the 1-based position of the placeholder.
+//
+// Question marks inside string literals ('...', "..." and @@...@@), comments
+// (-- and /* */) and backtick-quoted identifiers are left as is,
+// ?? outside of them is an escaped question mark.
+func replacePlaceholders(sql string, replace func(i int) (string, error)) (string, error) {
 	buf := &bytes.Buffer{}
 	i := 0
 	for {
-		p := strings.Index(sql, "?")
+		p := strings.IndexAny(sql, "?'\"`@-/")
 		if p == -1 {
 			break
 		}
 
+		if sql[p] != '?' {
+			n := skipLen(sql[p:])
+			if n == 0 {
+				n = 1
+			}
+			buf.WriteString(sql[:p+n])
+			sql = sql[p+n:]
+			continue
+		}
+
 		if len(sql[p:]) > 1 && sql[p:p+2] == "??" { // escape ?? => ?
 			buf.WriteString(sql[:p])
 			buf.WriteString("?")
-			if len(sql[p:]) == 1 {
-				break
-			}
 			sql = sql[p+2:]
 		} else {
 			i++
+			placeholder, err := replace(i)
+			if err != nil {
+				return "", err
+			}
 			buf.WriteString(sql[:p])
-			fmt.Fprintf(buf, "%s%d", prefix, i)
+			buf.WriteString(placeholder)
 			sql = sql[p+1:]
 		}
 	}
@@ -121,3 +144,46 @@ func replacePositionalPlaceholders(sql, prefix string) (string, error) {
 	buf.WriteString(sql)
 	return buf.String(), nil
 }
+
+// skipLen returns the length of the string literal, comment or quoted identifier
+// at the beginning of sql, or 0 if there is none.
+// Unterminated ones last until the end of sql.
+func skipLen(sql string) int {
+	switch {
+	case sql[0] == '\'', sql[0] == '"', sql[0] == '`':
+		for i := 1; i < len(sql); i++ {
+			switch sql[i] {
+			case '\\':
+				i++
+			case sql[0]:
+				return i + 1
+			}
+		}
+		return len(sql)
+	case strings.HasPrefix(sql, "@@"):
+		// @@@@ inside is an escaped @@
+		for i := 2; i < len(sql); {
+			p := strings.Index(sql[i:], "@@")
+			if p == -1 {
+				break
+			}
+			i += p + 2
+			if !strings.HasPrefix(sql[i:], "@@") {
+				return i
+			}
+			i += 2
+		}
+		return len(sql)
+	case strings.HasPrefix(sql, "--"):
+		if p := strings.IndexByte(sql, '\n'); p != -1 {
+			return p + 1
+		}
+		return len(sql)
+	case strings.HasPrefix(sql, "/*"):
+		if p := strings.Index(sql[2:], "*/"); p != -1 {
+			return p + 4
+		}
+		return len(sql)
+	}
+	return 0
+}
@@ -26,13 +26,94 @@ func TestPlaceholders(t *testing.T) {
 func TestEscapeDollar(t *testing.T) {
 	sql := "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ??| array['?'] AND enabled = ?"
 	s, _ := Dollar.ReplacePlaceholders(sql)
-	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['$1'] AND enabled = $2", s)
+	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['?'] AND enabled = $1", s)
 }
 
 func TestEscapeDollarP(t *testing.T) {
 	sql := "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ??| array['?'] AND enabled = ?"
 	s, _ := DollarP.ReplacePlaceholders(sql)
-	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['$p1'] AND enabled = $p2", s)
+	assert.Equal(t, "SELECT uuid, \"data\" #> '{tags}' AS tags FROM nodes WHERE  \"data\" -> 'tags' ?| array['?'] AND enabled = $p1", s)
+}
+
+func TestDollarPSkipsLiterals(t *testing.T) {
+	cases := []struct {
+		sql      string
+		expected string
+	}{
+		{"x = '?' AND y = ?", "x = '?' AND y = $p1"},
+		{`x = "a\"?" AND y = ?`, `x = "a\"?" AND y = $p1`},
+		{`x = 'a\\' AND y = ?`, `x = 'a\\' AND y = $p1`},
+		{"x = @@a?@@@@?@@ AND y = ?", "x = @@a?@@@@?@@ AND y = $p1"},
+		{"x = ? -- y = ?\nAND z = ?", "x = $p1 -- y = ?\nAND z = $p2"},
+		{"x = ? /* y = ? */ AND z = ?", "x = $p1 /* y = ? */ AND z = $p2"},
+		{"SELECT `a?b` FROM t WHERE x = ?", "SELECT `a?b` FROM t WHERE x = $p1"},
+		{"x = ?-? AND y = ?/?", "x = $p1-$p2 AND y = $p3/$p4"},
+		{"x = ?? AND y = ?", "x = ? AND y = $p1"},
+		{"x = ? -- ?", "x = $p1 -- ?"},
+		{"x = 'unterminated ?", "x = 'unterminated ?"},
+	}
+	for _, c := range cases {
+		s, err := DollarP.ReplacePlaceholders(c.sql)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, s, c.sql)
+	}
+}
+
+func FuzzReplacePlaceholders(f *testing.F) {
+	f.Add("x = ? AND y = ?")
+	f.Add("x = '?' AND y = \"?\" AND z = ?")
+	f.Add("x = @@?@@@@?@@ -- ?\n/* ? */ `?` ??")
+	f.Add("'\\' ? @@ ? /* ?")
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		n := 0
+		s, err := replacePlaceholders(sql, func(i int) (string, error) {
+			n++
+			if i != n {
+				t.Fatalf("placeholder %d replaced as %d", n, i)
+			}
+			return "?", nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(sql, "??") && s != sql {
+			t.Fatalf("%q changed to %q", sql, s)
+		}
+		if n > strings.Count(sql, "?") {
+			t.Fatalf("%d placeholders in %q", n, sql)
+		}
+	})
+}
+
+func FuzzReplacePlaceholdersLiterals(f *testing.F) {
+	f.Add("?")
+	f.Add("a\"b'c`d?")
+	f.Add("@@?@@")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		skipped := []string{QuoteString(text), Ident(text), "'" + strings.ReplaceAll(strings.ReplaceAll(text, "\\", "\\\\"), "'", "\\'") + "'"}
+		if !strings.Contains(text, "@") {
+			skipped = append(skipped, "@@"+text+"@@")
+		}
+		if !strings.Contains(text, "*/") {
+			skipped = append(skipped, "/*"+text+"*/")
+		}
+		if !strings.Contains(text, "\n") {
+			skipped = append(skipped, "--"+text+"\n")
+		}
+
+		for _, literal := range skipped {
+			s, err := DollarP.ReplacePlaceholders("x = ? AND y = " + literal + " AND z = ?")
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := "x = $p1 AND y = " + literal + " AND z = $p2"
+			if s != expected {
+				t.Fatalf("expected %q, got %q", expected, s)
+			}
+		}
+	})
 }
 
 func BenchmarkPlaceholdersArray(b *testing.B) {
@@ -0,0 +1,222 @@
+package yqb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// SlotArg is a named arg of a prepared query, see Slot and Prepare.
+type SlotArg interface {
+	slotName() string
+	slotType() (types.Type, error)
+	slotValue(v any) (types.Value, error)
+	isList() bool
+}
+
+type slot[T any] struct {
+	name string
+	typ  types.Type
+	err  error
+}
+
+// Slot returns a named arg of type T, its value is set by Prepared.Bind.
+// Slices are bound as a single List<T> param, in Eq and NotEq they are
+// compared with IN.
+// Ex:
+//
+//	p, err := Prepare(Select("id").From("users").Where(Eq{"id": Slot[uint64]("id")}))
+//	// SELECT id FROM users WHERE id = $p1
+//	params, err := p.Bind(map[string]any{"id": uint64(1)})
+func Slot[T any](name string) SlotArg {
+	typ, err := slotTypeOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		err = fmt.Errorf("slot %q: %w", name, err)
+	}
+	return slot[T]{name: name, typ: typ, err: err}
+}
+
+func (s slot[T]) slotName() string {
+	return s.name
+}
+
+func (s slot[T]) slotType() (types.Type, error) {
+	return s.typ, s.err
+}
+
+func (s slot[T]) isList() bool {
+	return s.err == nil && isListSlotType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func (s slot[T]) slotValue(v any) (types.Value, error) {
+	if ydbVal, ok := v.(types.Value); ok {
+		if !types.Equal(ydbVal.Type(), s.typ) {
+			return nil, fmt.Errorf("slot %q expects %s, got %s", s.name, s.typ.Yql(), ydbVal.Type().Yql())
+		}
+		return ydbVal, nil
+	}
+
+	t, ok := v.(T)
+	if !ok {
+		return nil, fmt.Errorf("slot %q expects %T, got %T", s.name, t, v)
+	}
+
+	var val types.Value
+	if rv := reflect.ValueOf(t); isListSlotType(rv.Type()) {
+		if rv.Len() == 0 {
+			return types.ZeroValue(s.typ), nil
+		}
+		var err error
+		if val, err = listValue(rv); err != nil {
+			return nil, fmt.Errorf("slot %q: %w", s.name, err)
+		}
+	} else {
+		values, err := castArgToYdb(t)
+		if err != nil {
+			return nil, fmt.Errorf("slot %q: %w", s.name, err)
+		}
+		val = values[0]
+	}
+
+	if !types.Equal(val.Type(), s.typ) {
+		return nil, fmt.Errorf("slot %q expects %s, got %s", s.name, s.typ.Yql(), val.Type().Yql())
+	}
+	return val, nil
+}
+
+var (
+	bytesType      = reflect.TypeOf([]byte(nil))
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+func isListSlotType(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t != bytesType && t != rawMessageType
+}
+
+// slotTypeOf returns the YDB type of values of the Go type t.
+func slotTypeOf(t reflect.Type) (types.Type, error) {
+	switch {
+	case t.Kind() == reflect.Interface:
+		return nil, fmt.Errorf("unsupported type `%s`", t)
+	// a nil json.RawMessage is cast to NULL, so the types aren't taken from zero values
+	case t == bytesType:
+		return types.TypeBytes, nil
+	case t == rawMessageType:
+		return types.TypeJSON, nil
+	case t.Kind() == reflect.Ptr:
+		inner, err := slotTypeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.Optional(inner), nil
+	case isListSlotType(t):
+		item, err := slotTypeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.List(item), nil
+	}
+
+	values, err := castArgToYdb(reflect.Zero(t).Interface())
+	if err != nil {
+		return nil, err
+	}
+	return values[0].Type(), nil
+}
+
+// Prepared is a query rendered once, its slots are bound by Bind.
+type Prepared struct {
+	query  string
+	params []table.ParameterOption
+	slots  map[string]preparedSlot
+}
+
+type preparedSlot struct {
+	slot      SlotArg
+	positions []int
+}
+
+// Prepare renders the query text with DECLAREs and the params once, so
+// only the values of slots are set on every call by Bind.
+// Other args of the builder are bound as is.
+//
+// Prepare returns an error if a slot has an unsupported type or several
+// slots with the same name have different types.
+func Prepare(b YdbSqlizer) (*Prepared, error) {
+	s, ok := b.(Sqlizer)
+	if !ok {
+		return nil, fmt.Errorf("expected Sqlizer, not %T", b)
+	}
+
+	query, params, err := b.ToYdbSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, args, err := nestedToSql(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(params) {
+		return nil, fmt.Errorf("got %d args for %d params", len(args), len(params))
+	}
+
+	p := &Prepared{query: query, params: params, slots: map[string]preparedSlot{}}
+	for i, arg := range args {
+		slot, ok := arg.(SlotArg)
+		if !ok {
+			continue
+		}
+		ps, ok := p.slots[slot.slotName()]
+		if ok {
+			prevType, _ := ps.slot.slotType()
+			slotType, _ := slot.slotType()
+			if !types.Equal(prevType, slotType) {
+				return nil, fmt.Errorf("slot %q has types %s and %s", slot.slotName(), prevType.Yql(), slotType.Yql())
+			}
+		} else {
+			ps.slot = slot
+		}
+		ps.positions = append(ps.positions, i)
+		p.slots[slot.slotName()] = ps
+	}
+
+	return p, nil
+}
+
+// Query returns the query text with DECLAREs.
+func (p *Prepared) Query() string {
+	return p.query
+}
+
+// Bind returns the params of the query with values of slots by name.
+// Every slot must have a value of its type.
+func (p *Prepared) Bind(values map[string]any) ([]table.ParameterOption, error) {
+	if len(values) != len(p.slots) {
+		for name := range values {
+			if _, ok := p.slots[name]; !ok {
+				return nil, fmt.Errorf("unknown slot %q", name)
+			}
+		}
+	}
+
+	params := make([]table.ParameterOption, len(p.params))
+	copy(params, p.params)
+	for name, ps := range p.slots {
+		v, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("no value for slot %q", name)
+		}
+		val, err := ps.slot.slotValue(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range ps.positions {
+			params[i] = table.ValueParam(fmt.Sprintf("p%d", i+1), val)
+		}
+	}
+	return params, nil
+}
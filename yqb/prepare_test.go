@@ -0,0 +1,99 @@
+package yqb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestPrepare(t *testing.T) {
+	b := Select("id").
+		From("users").
+		Where(Eq{"status": uint32(1), "id": Slot[[]uint64]("ids")}).
+		Where("(name = ? OR nick = ?)", Slot[*string]("name"), Slot[*string]("name"))
+	p, err := Prepare(b)
+	assert.NoError(t, err)
+
+	expectedQuery := "DECLARE $p1 AS List<Uint64>;\n" +
+		"DECLARE $p2 AS Uint32;\n" +
+		"DECLARE $p3 AS Optional<Utf8>;\n" +
+		"DECLARE $p4 AS Optional<Utf8>;\n" +
+		"SELECT id FROM users WHERE id IN $p1 AND status = $p2 AND (name = $p3 OR nick = $p4)"
+	assert.Equal(t, expectedQuery, p.Query())
+
+	name := "a"
+	params, err := p.Bind(map[string]any{"ids": []uint64{1, 2}, "name": &name})
+	assert.NoError(t, err)
+
+	expectedParams := table.NewQueryParameters(
+		table.ValueParam("p1", types.ListValue(types.Uint64Value(1), types.Uint64Value(2))),
+		table.ValueParam("p2", types.Uint32Value(1)),
+		table.ValueParam("p3", types.OptionalValue(types.TextValue("a"))),
+		table.ValueParam("p4", types.OptionalValue(types.TextValue("a"))),
+	)
+	assert.Equal(t, expectedParams.String(), table.NewQueryParameters(params...).String())
+
+	params, err = p.Bind(map[string]any{"ids": []uint64{}, "name": types.NullValue(types.TypeText)})
+	assert.NoError(t, err)
+	assert.Len(t, params, 4)
+}
+
+func TestPrepareNilPointers(t *testing.T) {
+	p, err := Prepare(Select("id").From("users").Where("a = ? AND b = ?", Slot[*int]("a"), Slot[*uint]("b")))
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS Optional<Int64>;\n"+
+		"DECLARE $p2 AS Optional<Uint64>;\n"+
+		"SELECT id FROM users WHERE a = $p1 AND b = $p2", p.Query())
+
+	params, err := p.Bind(map[string]any{"a": (*int)(nil), "b": (*uint)(nil)})
+	assert.NoError(t, err)
+
+	expectedParams := table.NewQueryParameters(
+		table.ValueParam("p1", types.NullValue(types.TypeInt64)),
+		table.ValueParam("p2", types.NullValue(types.TypeUint64)),
+	)
+	assert.Equal(t, expectedParams.String(), table.NewQueryParameters(params...).String())
+}
+
+func TestPrepareBytes(t *testing.T) {
+	p, err := Prepare(Select("id").From("users").Where("avatar = ? AND payload = ?", Slot[[]byte]("avatar"), Slot[json.RawMessage]("payload")))
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS String;\n"+
+		"DECLARE $p2 AS Json;\n"+
+		"SELECT id FROM users WHERE avatar = $p1 AND payload = $p2", p.Query())
+
+	params, err := p.Bind(map[string]any{"avatar": []byte("a"), "payload": json.RawMessage(`{"a":1}`)})
+	assert.NoError(t, err)
+
+	expectedParams := table.NewQueryParameters(
+		table.ValueParam("p1", types.BytesValue([]byte("a"))),
+		table.ValueParam("p2", types.JSONValueFromBytes([]byte(`{"a":1}`))),
+	)
+	assert.Equal(t, expectedParams.String(), table.NewQueryParameters(params...).String())
+}
+
+func TestPrepareErrors(t *testing.T) {
+	_, err := Prepare(Select("id").From("users").Where("a = ? AND b = ?", Slot[uint64]("x"), Slot[int64]("x")))
+	assert.Error(t, err)
+
+	_, err = Prepare(Select("id").From("users").Where("a = ?", Slot[any]("x")))
+	assert.Error(t, err)
+
+	p, err := Prepare(Select("id").From("users").Where("a = ?", Slot[uint64]("x")))
+	assert.NoError(t, err)
+
+	_, err = p.Bind(map[string]any{"x": int64(1)})
+	assert.Error(t, err)
+
+	_, err = p.Bind(map[string]any{"x": types.Int64Value(1)})
+	assert.Error(t, err)
+
+	_, err = p.Bind(map[string]any{})
+	assert.Error(t, err)
+
+	_, err = p.Bind(map[string]any{"x": uint64(1), "y": 1})
+	assert.Error(t, err)
+}
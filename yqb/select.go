@@ -6,29 +6,28 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
 type selectData struct {
-	PlaceholderFormat PlaceholderFormat
-	RunWith           BaseRunner
-	Prefixes          []Sqlizer
-	Options           []string
-	Columns           []Sqlizer
-	From              Sqlizer
-	Index             Sqlizer
-	WithParts         []Sqlizer
-	Flatten           []Sqlizer
-	Joins             []Sqlizer
-	WhereParts        []Sqlizer
-	GroupBys          []string
-	HavingParts       []Sqlizer
-	AssumeOrderBy     string
-	OrderByParts      []Sqlizer
-	Limit             string
-	Offset            string
-	Suffixes          []Sqlizer
+	statementOptions
+
+	Prefixes      []Sqlizer
+	Options       []string
+	Columns       []Sqlizer
+	From          Sqlizer
+	Index         Sqlizer
+	WithParts     []Sqlizer
+	Flatten       []Sqlizer
+	Joins         []Sqlizer
+	WhereParts    []Sqlizer
+	GroupBys      []string
+	HavingParts   []Sqlizer
+	AssumeOrderBy string
+	OrderByParts  []Sqlizer
+	Limit         Sqlizer
+	Offset        Sqlizer
+	Suffixes      []Sqlizer
 }
 
 func (d *selectData) Exec() (sql.Result, error) {
@@ -75,6 +74,10 @@ func (d *selectData) ToSql() (sqlStr string, args []any, err error) {
 	return
 }
 
+func (d *selectData) predicateOptions() predicateOptions {
+	return predicateOptions{listParams: d.ListParams, identMode: d.IdentMode}
+}
+
 func (d *selectData) toSqlRaw() (sqlStr string, args []any, err error) {
 	if len(d.Columns) == 0 {
 		err = fmt.Errorf("select statements must have at least one result column")
@@ -107,16 +110,28 @@ func (d *selectData) toSqlRaw() (sqlStr string, args []any, err error) {
 	}
 
 	if d.From != nil {
+		var from Sqlizer
+		from, err = d.IdentMode.applyPart(d.From)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" FROM ")
-		args, err = appendToSql([]Sqlizer{d.From}, sql, "", args)
+		args, err = appendToSql([]Sqlizer{from}, sql, "", args)
 		if err != nil {
 			return
 		}
 	}
 
 	if d.Index != nil {
+		var index Sqlizer
+		index, err = d.IdentMode.applyPart(d.Index)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" VIEW ")
-		args, err = appendToSql([]Sqlizer{d.Index}, sql, "", args)
+		args, err = appendToSql([]Sqlizer{index}, sql, "", args)
 		if err != nil {
 			return
 		}
@@ -147,21 +162,39 @@ func (d *selectData) toSqlRaw() (sqlStr string, args []any, err error) {
 	}
 
 	if len(d.WhereParts) > 0 {
+		var whereParts []Sqlizer
+		whereParts, err = d.predicateOptions().applyAll(d.WhereParts)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
+		args, err = appendToSql(whereParts, sql, " AND ", args)
 		if err != nil {
 			return
 		}
 	}
 
 	if len(d.GroupBys) > 0 {
+		var groupBys []string
+		groupBys, err = d.IdentMode.applyAll(d.GroupBys)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" GROUP BY ")
-		sql.WriteString(strings.Join(d.GroupBys, ", "))
+		sql.WriteString(strings.Join(groupBys, ", "))
 	}
 
 	if len(d.HavingParts) > 0 {
+		var havingParts []Sqlizer
+		havingParts, err = d.predicateOptions().applyAll(d.HavingParts)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" HAVING ")
-		args, err = appendToSql(d.HavingParts, sql, " AND ", args)
+		args, err = appendToSql(havingParts, sql, " AND ", args)
 		if err != nil {
 			return
 		}
@@ -173,21 +206,31 @@ func (d *selectData) toSqlRaw() (sqlStr string, args []any, err error) {
 	}
 
 	if len(d.OrderByParts) > 0 {
+		var orderByParts []Sqlizer
+		orderByParts, err = d.IdentMode.applyList(d.OrderByParts)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" ORDER BY ")
-		args, err = appendToSql(d.OrderByParts, sql, ", ", args)
+		args, err = appendToSql(orderByParts, sql, ", ", args)
 		if err != nil {
 			return
 		}
 	}
 
-	if len(d.Limit) > 0 {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(d.Limit)
+	if d.Limit != nil {
+		args, err = appendLimitToSql(" LIMIT ", d.Limit, d.LimitParams, sql, args)
+		if err != nil {
+			return
+		}
 	}
 
-	if len(d.Offset) > 0 {
-		sql.WriteString(" OFFSET ")
-		sql.WriteString(d.Offset)
+	if d.Offset != nil {
+		args, err = appendLimitToSql(" OFFSET ", d.Offset, d.LimitParams, sql, args)
+		if err != nil {
+			return
+		}
 	}
 
 	if len(d.Suffixes) > 0 {
@@ -206,10 +249,12 @@ func (d *selectData) toSqlRaw() (sqlStr string, args []any, err error) {
 // Builder
 
 // SelectBuilder builds SQL SELECT statements.
-type SelectBuilder builder.Builder
+type SelectBuilder struct {
+	d *selectData
+}
 
-func init() {
-	builder.Register(SelectBuilder{}, selectData{})
+func (b SelectBuilder) data() *selectData {
+	return dataOf(b.d)
 }
 
 // Format methods
@@ -217,7 +262,57 @@ func init() {
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b SelectBuilder) PlaceholderFormat(f PlaceholderFormat) SelectBuilder {
-	return builder.Set(b, "PlaceholderFormat", f).(SelectBuilder)
+	d := clone(b.d)
+	d.PlaceholderFormat = f
+	return SelectBuilder{d}
+}
+
+// ListParams sets whether slices in Eq and NotEq predicates are bound as
+// a single List<T> parameter.
+//
+// See StatementBuilderType.ListParams.
+func (b SelectBuilder) ListParams(enabled bool) SelectBuilder {
+	d := clone(b.d)
+	d.ListParams = enabled
+	return SelectBuilder{d}
+}
+
+// LimitParams sets whether numbers of LIMIT and OFFSET clauses are bound as
+// Uint64 parameters.
+//
+// See StatementBuilderType.LimitParams.
+func (b SelectBuilder) LimitParams(enabled bool) SelectBuilder {
+	d := clone(b.d)
+	d.LimitParams = enabled
+	return SelectBuilder{d}
+}
+
+// IdentMode sets IdentMode (e.g. IdentQuote or IdentValidate) for table and
+// column names of the query.
+//
+// See StatementBuilderType.IdentMode.
+func (b SelectBuilder) IdentMode(mode IdentMode) SelectBuilder {
+	d := clone(b.d)
+	d.IdentMode = mode
+	return SelectBuilder{d}
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b SelectBuilder) OmitDeclare(omit bool) SelectBuilder {
+	d := clone(b.d)
+	d.OmitDeclare = omit
+	return SelectBuilder{d}
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b SelectBuilder) Limits(limits Limits) SelectBuilder {
+	d := clone(b.d)
+	d.Limits = limits
+	return SelectBuilder{d}
 }
 
 // Runner methods
@@ -227,24 +322,26 @@ func (b SelectBuilder) PlaceholderFormat(f PlaceholderFormat) SelectBuilder {
 //
 // Internally we use this to mock out the database connection for testing.
 func (b SelectBuilder) RunWith(runner BaseRunner) SelectBuilder {
-	return setRunWith(b, runner).(SelectBuilder)
+	d := clone(b.d)
+	d.RunWith = wrapRunner(runner)
+	return SelectBuilder{d}
 }
 
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b SelectBuilder) Exec() (sql.Result, error) {
-	data := builder.GetStruct(b).(selectData)
+	data := b.data()
 	return data.Exec()
 }
 
 // Query builds and Querys the query with the Runner set by RunWith.
 func (b SelectBuilder) Query() (*sql.Rows, error) {
-	data := builder.GetStruct(b).(selectData)
+	data := b.data()
 	return data.Query()
 }
 
 // QueryRow builds and QueryRows the query with the Runner set by RunWith.
 func (b SelectBuilder) QueryRow() RowScanner {
-	data := builder.GetStruct(b).(selectData)
+	data := b.data()
 	return data.QueryRow()
 }
 
@@ -257,12 +354,12 @@ func (b SelectBuilder) Scan(dest ...any) error {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b SelectBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(selectData)
+	data := b.data()
 	return data.ToSql()
 }
 
 func (b SelectBuilder) toSqlRaw() (string, []any, error) {
-	data := builder.GetStruct(b).(selectData)
+	data := b.data()
 	return data.toSqlRaw()
 }
 
@@ -273,7 +370,7 @@ func (b SelectBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
 	}
 
-	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args)
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, b.data().OmitDeclare)
 	if err != nil {
 		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
 	}
@@ -283,6 +380,10 @@ func (b SelectBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
 	}
 
+	if err = checkLimits(b.data().Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
 	return ydbSqlStr, ydbArgs, err
 }
 
@@ -293,7 +394,9 @@ func (b SelectBuilder) Prefix(sql string, args ...any) SelectBuilder {
 
 // PrefixExpr adds an expression to the very beginning of the query
 func (b SelectBuilder) PrefixExpr(expr Sqlizer) SelectBuilder {
-	return builder.Append(b, "Prefixes", expr).(SelectBuilder)
+	d := clone(b.d)
+	d.Prefixes = appendCopy(d.Prefixes, expr)
+	return SelectBuilder{d}
 }
 
 // Distinct adds a DISTINCT clause to the query.
@@ -303,23 +406,29 @@ func (b SelectBuilder) Distinct() SelectBuilder {
 
 // Options adds select option to the query
 func (b SelectBuilder) Options(options ...string) SelectBuilder {
-	return builder.Extend(b, "Options", options).(SelectBuilder)
+	d := clone(b.d)
+	d.Options = appendCopy(d.Options, options...)
+	return SelectBuilder{d}
 }
 
 // Columns adds result columns to the query.
 func (b SelectBuilder) Columns(columns ...string) SelectBuilder {
-	parts := make([]any, 0, len(columns))
+	parts := make([]Sqlizer, 0, len(columns))
 	for _, str := range columns {
 		parts = append(parts, newPart(str))
 	}
-	return builder.Extend(b, "Columns", parts).(SelectBuilder)
+	d := clone(b.d)
+	d.Columns = appendCopy(d.Columns, parts...)
+	return SelectBuilder{d}
 }
 
 // RemoveColumns remove all columns from query.
 // Must add a new column with Column or Columns methods, otherwise
 // return a error.
 func (b SelectBuilder) RemoveColumns() SelectBuilder {
-	return builder.Delete(b, "Columns").(SelectBuilder)
+	d := clone(b.d)
+	d.Columns = nil
+	return SelectBuilder{d}
 }
 
 // Column adds a result column to the query.
@@ -328,36 +437,48 @@ func (b SelectBuilder) RemoveColumns() SelectBuilder {
 //
 //	Column("IF(col IN ("+squirrel.Placeholders(3)+"), 1, 0) as col", 1, 2, 3)
 func (b SelectBuilder) Column(column any, args ...any) SelectBuilder {
-	return builder.Append(b, "Columns", newPart(column, args...)).(SelectBuilder)
+	d := clone(b.d)
+	d.Columns = appendCopy(d.Columns, newPart(column, args...))
+	return SelectBuilder{d}
 }
 
 // From sets the FROM clause of the query.
 func (b SelectBuilder) From(from string) SelectBuilder {
-	return builder.Set(b, "From", newPart(from)).(SelectBuilder)
+	d := clone(b.d)
+	d.From = newPart(from)
+	return SelectBuilder{d}
 }
 
 // FromSelect sets a subquery into the FROM clause of the query.
 func (b SelectBuilder) FromSelect(from SelectBuilder, alias string) SelectBuilder {
 	// Prevent misnumbered parameters in nested selects (#183).
 	from = from.PlaceholderFormat(Question)
-	return builder.Set(b, "From", Alias(from, alias)).(SelectBuilder)
+	d := clone(b.d)
+	d.From = Alias(from, alias)
+	return SelectBuilder{d}
 }
 
 // View sets the VIEW clause of the query.
 func (b SelectBuilder) View(view string) SelectBuilder {
-	return builder.Set(b, "Index", newPart(view)).(SelectBuilder)
+	d := clone(b.d)
+	d.Index = newPart(view)
+	return SelectBuilder{d}
 }
 
 // With adds an expression to the WITH clause of the query.
 //
 // See Where.
 func (b SelectBuilder) With(pred any, rest ...any) SelectBuilder {
-	return builder.Append(b, "WithParts", newWherePart(pred, rest...)).(SelectBuilder)
+	d := clone(b.d)
+	d.WithParts = appendCopy(d.WithParts, newWherePart(pred, rest...))
+	return SelectBuilder{d}
 }
 
 // FlattenClause adds a flatten clause to the query.
 func (b SelectBuilder) FlattenClause(pred any, args ...any) SelectBuilder {
-	return builder.Append(b, "Flatten", newPart(pred, args...)).(SelectBuilder)
+	d := clone(b.d)
+	d.Flatten = appendCopy(d.Flatten, newPart(pred, args...))
+	return SelectBuilder{d}
 }
 
 // Flatten adds a FLATTEN BY clause to the query.
@@ -387,7 +508,9 @@ func (b SelectBuilder) FlattenColumns(flatten string, rest ...any) SelectBuilder
 
 // JoinClause adds a join clause to the query.
 func (b SelectBuilder) JoinClause(pred any, args ...any) SelectBuilder {
-	return builder.Append(b, "Joins", newPart(pred, args...)).(SelectBuilder)
+	d := clone(b.d)
+	d.Joins = appendCopy(d.Joins, newPart(pred, args...))
+	return SelectBuilder{d}
 }
 
 // Join adds a JOIN clause to the query.
@@ -439,58 +562,110 @@ func (b SelectBuilder) Where(pred any, args ...any) SelectBuilder {
 	if pred == nil || pred == "" {
 		return b
 	}
-	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(SelectBuilder)
+	d := clone(b.d)
+	d.WhereParts = appendCopy(d.WhereParts, newWherePart(pred, args...))
+	return SelectBuilder{d}
 }
 
 // GroupBy adds GROUP BY expressions to the query.
+//
+// The IdentMode of the builder is applied to them, they must be columns unless it's IdentAsIs.
 func (b SelectBuilder) GroupBy(groupBys ...string) SelectBuilder {
-	return builder.Extend(b, "GroupBys", groupBys).(SelectBuilder)
+	d := clone(b.d)
+	d.GroupBys = appendCopy(d.GroupBys, groupBys...)
+	return SelectBuilder{d}
 }
 
 // Having adds an expression to the HAVING clause of the query.
 //
 // See Where.
 func (b SelectBuilder) Having(pred any, rest ...any) SelectBuilder {
-	return builder.Append(b, "HavingParts", newWherePart(pred, rest...)).(SelectBuilder)
+	d := clone(b.d)
+	d.HavingParts = appendCopy(d.HavingParts, newWherePart(pred, rest...))
+	return SelectBuilder{d}
 }
 
 // AssumeOrderBy adds a ASSUME clause to the query.
 func (b SelectBuilder) AssumeOrderBy() SelectBuilder {
-	return builder.Set(b, "AssumeOrderBy", "ASSUME").(SelectBuilder)
+	d := clone(b.d)
+	d.AssumeOrderBy = "ASSUME"
+	return SelectBuilder{d}
 }
 
 // OrderByClause adds ORDER BY clause to the query.
 func (b SelectBuilder) OrderByClause(pred any, args ...any) SelectBuilder {
-	return builder.Append(b, "OrderByParts", newPart(pred, args...)).(SelectBuilder)
+	d := clone(b.d)
+	d.OrderByParts = appendCopy(d.OrderByParts, newPart(pred, args...))
+	return SelectBuilder{d}
 }
 
 // OrderBy adds ORDER BY expressions to the query.
+//
+// The IdentMode of the builder is applied to their columns, e.g. "created_at DESC",
+// use OrderByClause for other expressions.
 func (b SelectBuilder) OrderBy(orderBys ...string) SelectBuilder {
+	d := clone(b.d)
 	for _, orderBy := range orderBys {
-		b = b.OrderByClause(orderBy)
+		d.OrderByParts = appendCopy(d.OrderByParts, Sqlizer(orderByColumn(orderBy)))
 	}
-
-	return b
+	return SelectBuilder{d}
 }
 
 // Limit sets a LIMIT clause on the query.
 func (b SelectBuilder) Limit(limit uint64) SelectBuilder {
-	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(SelectBuilder)
+	d := clone(b.d)
+	d.Limit = limitValue(limit)
+	return SelectBuilder{d}
 }
 
 // Limit ALL allows to access all records with limit
 func (b SelectBuilder) RemoveLimit() SelectBuilder {
-	return builder.Delete(b, "Limit").(SelectBuilder)
+	d := clone(b.d)
+	d.Limit = nil
+	return SelectBuilder{d}
 }
 
 // Offset sets a OFFSET clause on the query.
 func (b SelectBuilder) Offset(offset uint64) SelectBuilder {
-	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(SelectBuilder)
+	d := clone(b.d)
+	d.Offset = limitValue(offset)
+	return SelectBuilder{d}
+}
+
+// LimitExpr sets a LIMIT clause on the query with an expression, e.g. Expr("$limit").
+func (b SelectBuilder) LimitExpr(expr Sqlizer) SelectBuilder {
+	d := clone(b.d)
+	d.Limit = expr
+	return SelectBuilder{d}
+}
+
+// OffsetExpr sets a OFFSET clause on the query with an expression, e.g. Expr("$offset").
+func (b SelectBuilder) OffsetExpr(expr Sqlizer) SelectBuilder {
+	d := clone(b.d)
+	d.Offset = expr
+	return SelectBuilder{d}
 }
 
 // RemoveOffset removes OFFSET clause.
 func (b SelectBuilder) RemoveOffset() SelectBuilder {
-	return builder.Delete(b, "Offset").(SelectBuilder)
+	d := clone(b.d)
+	d.Offset = nil
+	return SelectBuilder{d}
+}
+
+// HasOrderBy reports whether the query has an ORDER BY clause.
+func (b SelectBuilder) HasOrderBy() bool {
+	return len(b.data().OrderByParts) > 0
+}
+
+// HasLimit reports whether the query has a LIMIT clause.
+func (b SelectBuilder) HasLimit() bool {
+	return b.data().Limit != nil
+}
+
+// HasOffset reports whether the query has an OFFSET clause.
+func (b SelectBuilder) HasOffset() bool {
+	return b.data().Offset != nil
 }
 
 // Suffix adds an expression to the end of the query
@@ -500,5 +675,7 @@ func (b SelectBuilder) Suffix(sql string, args ...any) SelectBuilder {
 
 // SuffixExpr adds an expression to the end of the query
 func (b SelectBuilder) SuffixExpr(expr Sqlizer) SelectBuilder {
-	return builder.Append(b, "Suffixes", expr).(SelectBuilder)
+	d := clone(b.d)
+	d.Suffixes = appendCopy(d.Suffixes, expr)
+	return SelectBuilder{d}
 }
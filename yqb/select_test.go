@@ -291,6 +291,28 @@ func TestSelectWithRemoveOffset(t *testing.T) {
 	assert.Equal(t, "SELECT * FROM foo", sql)
 }
 
+func TestSelectHasOrderByLimitOffset(t *testing.T) {
+	b := Select("*").From("foo")
+	assert.False(t, b.HasOrderBy())
+	assert.False(t, b.HasLimit())
+	assert.False(t, b.HasOffset())
+
+	b = b.OrderBy("id").Limit(10).OffsetExpr(Expr("$offset"))
+	assert.True(t, b.HasOrderBy())
+	assert.True(t, b.HasLimit())
+	assert.True(t, b.HasOffset())
+
+	assert.False(t, SelectBuilder{}.HasOrderBy())
+}
+
+func TestSelectWithLimitExpr(t *testing.T) {
+	sql, args, err := Select("*").From("foo").Where("x = ?", 1).LimitExpr(Expr("?", uint64(10))).OffsetExpr(Expr("$offset")).ToSql()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM foo WHERE x = $p1 LIMIT $p2 OFFSET $offset", sql)
+	assert.Equal(t, []any{types.Int64Value(1), types.Uint64Value(10)}, args)
+}
+
 func TestSelectBuilderNestedSelectDollar(t *testing.T) {
 	nestedBuilder := StatementBuilder.PlaceholderFormat(Dollar).Select("*").Prefix("NOT EXISTS (").
 		From("bar").Where("y = ?", 42).Suffix(")")
@@ -0,0 +1,147 @@
+package yqb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+)
+
+// Fields is an allow-list of fields of API input mapped to column names.
+// Ex:
+//
+//	Fields{"id": "id", "created": "created_at"}
+type Fields map[string]string
+
+// FieldsOf returns Fields of the columns with the same field names,
+// e.g. of the table columns generated by ysg.
+func FieldsOf(columns ...string) Fields {
+	fields := make(Fields, len(columns))
+	for _, column := range columns {
+		fields[column] = column
+	}
+	return fields
+}
+
+// FieldsOfTable returns Fields of the columns of the table description,
+// see table.Session.DescribeTable.
+func FieldsOfTable(desc options.Description) Fields {
+	fields := make(Fields, len(desc.Columns))
+	for _, column := range desc.Columns {
+		fields[column.Name] = column.Name
+	}
+	return fields
+}
+
+// UnknownFieldError is returned by SortSpec and ProjectionSpec for fields
+// that aren't in the allow-list.
+type UnknownFieldError struct {
+	// Field is the field as written in the input.
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// SortSpec parses sort orders of API input, e.g. "-created_at,name".
+type SortSpec struct {
+	// Fields are the fields allowed to sort by.
+	Fields Fields
+	// Default are the ORDER BY parts for empty input, Parse returns a copy of them.
+	Default []string
+}
+
+// Parse returns the ORDER BY parts of the comma separated fields,
+// fields with the "-" prefix are sorted in descending order.
+// It returns *UnknownFieldError for fields that aren't in the allow-list.
+// Ex:
+//
+//	spec := SortSpec{Fields: FieldsOf("created_at", "name")}
+//	orderBys, err := spec.Parse("-created_at,name")
+//	// orderBys == []string{"created_at DESC", "name"}
+//	Select("*").From("users").OrderBy(orderBys...)
+func (s SortSpec) Parse(sort string) ([]string, error) {
+	items := splitSpec(sort)
+	if len(items) == 0 {
+		return append([]string(nil), s.Default...), nil
+	}
+
+	orderBys := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		field, desc := item, false
+		switch {
+		case strings.HasPrefix(item, "-"):
+			field, desc = item[1:], true
+		case strings.HasPrefix(item, "+"):
+			field = item[1:]
+		}
+
+		column, ok := s.Fields[field]
+		if !ok || field == "" {
+			return nil, &UnknownFieldError{Field: item}
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+
+		if desc {
+			column += " DESC"
+		}
+		orderBys = append(orderBys, column)
+	}
+	return orderBys, nil
+}
+
+// ProjectionSpec parses field projections of API input, e.g. "id,name".
+type ProjectionSpec struct {
+	// Fields are the fields allowed to select.
+	Fields Fields
+	// Default are the columns for empty input, Parse returns a copy of them.
+	Default []string
+}
+
+// Parse returns the columns of the comma separated fields, repeated fields are skipped.
+// It returns *UnknownFieldError for fields that aren't in the allow-list.
+// Ex:
+//
+//	spec := ProjectionSpec{Fields: FieldsOf("id", "name", "email"), Default: []string{"id"}}
+//	columns, err := spec.Parse("id,name")
+//	// columns == []string{"id", "name"}
+//	Select(columns...).From("users")
+func (p ProjectionSpec) Parse(fields string) ([]string, error) {
+	items := splitSpec(fields)
+	if len(items) == 0 {
+		return append([]string(nil), p.Default...), nil
+	}
+
+	columns := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		column, ok := p.Fields[item]
+		if !ok {
+			return nil, &UnknownFieldError{Field: item}
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// splitSpec splits the comma separated input into trimmed items, it returns
+// nil for blank input.
+func splitSpec(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	items := strings.Split(s, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
@@ -0,0 +1,68 @@
+package yqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+)
+
+func TestSortSpec(t *testing.T) {
+	spec := SortSpec{
+		Fields:  Fields{"created": "created_at", "name": "name"},
+		Default: []string{"id"},
+	}
+
+	orderBys, err := spec.Parse("-created, +name,created")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"created_at DESC", "name"}, orderBys)
+
+	sql, _, err := Select("id").From("users").OrderBy(orderBys...).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM users ORDER BY created_at DESC, name", sql)
+
+	orderBys, err = spec.Parse(" ")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id"}, orderBys)
+
+	orderBys[0] = "name DESC"
+	assert.Equal(t, []string{"id"}, spec.Default)
+
+	for _, sort := range []string{"created_at", "-", "name,,created", "name; DROP TABLE users"} {
+		_, err = spec.Parse(sort)
+		var fieldErr *UnknownFieldError
+		assert.ErrorAs(t, err, &fieldErr, sort)
+	}
+
+	_, err = spec.Parse("-password")
+	assert.EqualError(t, err, `unknown field "-password"`)
+}
+
+func TestProjectionSpec(t *testing.T) {
+	spec := ProjectionSpec{
+		Fields:  FieldsOf("id", "name", "email"),
+		Default: []string{"id"},
+	}
+
+	columns, err := spec.Parse("name,id,name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name", "id"}, columns)
+
+	columns, err = spec.Parse("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id"}, columns)
+
+	columns[0] = "email"
+	assert.Equal(t, []string{"id"}, spec.Default)
+
+	_, err = spec.Parse("id,password")
+	assert.Equal(t, &UnknownFieldError{Field: "password"}, err)
+}
+
+func TestFieldsOfTable(t *testing.T) {
+	desc := options.Description{
+		Name:    "users",
+		Columns: []options.Column{{Name: "id"}, {Name: "name"}},
+	}
+	assert.Equal(t, Fields{"id": "id", "name": "name"}, FieldsOfTable(desc))
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 )
@@ -87,12 +86,13 @@ func (r *stdsqlRunner) QueryRow(query string, args ...any) RowScanner {
 	return r.StdSql.QueryRow(query, args...)
 }
 
-func setRunWith(b any, runner BaseRunner) any {
+// wrapRunner wraps runners implementing the standard SQL interface, see WrapStdSql.
+func wrapRunner(runner BaseRunner) BaseRunner {
 	switch r := runner.(type) {
 	case StdSql:
-		runner = WrapStdSql(r)
+		return WrapStdSql(r)
 	}
-	return builder.Set(b, "RunWith", runner)
+	return runner
 }
 
 // RunnerNotSet is returned by methods that need a Runner if it isn't set.
@@ -125,14 +125,35 @@ func QueryRowWith(db QueryRower, s Sqlizer) RowScanner {
 	return &Row{RowScanner: db.QueryRow(query, args...), err: err}
 }
 
-func prepareYdbSqlString(sql string, args []any) (string, error) {
+// prepareYdbSqlString adds DECLARE statements of the params to the query.
+// Params already declared in the query (e.g. by hand in Prefix) aren't declared
+// again if the types match, no DECLAREs are added if omitDeclare is true.
+func prepareYdbSqlString(sql string, args []any, omitDeclare bool) (string, error) {
+	declares, err := parseDeclares(sql)
+	if err != nil {
+		return "", err
+	}
+
 	var sb strings.Builder
 	for i, arg := range args {
 		ydbArg, ok := arg.(types.Value)
 		if !ok {
 			return "", fmt.Errorf("arg %T is not ydb.Value", arg)
 		}
-		sb.WriteString(fmt.Sprintf("DECLARE $p%d AS ", i+1))
+
+		name := fmt.Sprintf("$p%d", i+1)
+		if d, ok := declares[name]; ok {
+			if !sameType(d.typ, ydbArg.Type().Yql()) {
+				return "", fmt.Errorf("%w: %s is declared as %s, but the param is %s",
+					ErrDeclareTypeMismatch, name, d.typ, ydbArg.Type().Yql())
+			}
+			continue
+		}
+		if omitDeclare {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("DECLARE %s AS ", name))
 		sb.WriteString(ydbArg.Type().Yql())
 		sb.WriteString(";\n")
 	}
@@ -182,6 +203,15 @@ func castArgsToYdb(args []any) ([]any, error) {
 
 func castArgToYdb(arg any) ([]types.Value, error) {
 	switch t := arg.(type) {
+	case SlotArg:
+		// slots are bound by Prepared.Bind, the zero value keeps the type
+		slotType, err := t.slotType()
+		if err != nil {
+			return nil, err
+		}
+		return []types.Value{
+			types.ZeroValue(slotType),
+		}, nil
 	case bool:
 		return []types.Value{
 			types.BoolValue(t),
@@ -195,6 +225,11 @@ func castArgToYdb(arg any) ([]types.Value, error) {
 			types.Int64Value(int64(t)),
 		}, nil
 	case *int:
+		if t == nil {
+			return []types.Value{
+				types.NullValue(types.TypeInt64),
+			}, nil
+		}
 		tt := int64(*t)
 		return []types.Value{
 			types.NullableInt64Value(&tt),
@@ -236,6 +271,11 @@ func castArgToYdb(arg any) ([]types.Value, error) {
 			types.Uint64Value(uint64(t)),
 		}, nil
 	case *uint:
+		if t == nil {
+			return []types.Value{
+				types.NullValue(types.TypeUint64),
+			}, nil
+		}
 		tt := uint64(*t)
 		return []types.Value{
 			types.NullableUint64Value(&tt),
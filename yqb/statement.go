@@ -1,65 +1,146 @@
 package yqb
 
-import "github.com/lann/builder"
-
 // StatementBuilderType is the type of StatementBuilder.
-type StatementBuilderType builder.Builder
+type StatementBuilderType struct {
+	options    statementOptions
+	whereParts []Sqlizer
+}
 
 // Select returns a SelectBuilder for this StatementBuilderType.
 func (b StatementBuilderType) Select(columns ...string) SelectBuilder {
-	return SelectBuilder(b).Columns(columns...)
+	return SelectBuilder{&selectData{statementOptions: b.options, WhereParts: b.whereParts}}.Columns(columns...)
 }
 
 // Insert returns a InsertBuilder for this StatementBuilderType.
 func (b StatementBuilderType) Insert(into string) InsertBuilder {
-	return InsertBuilder(b).Into(into)
+	return InsertBuilder{&insertData{statementOptions: b.options}}.Into(into)
 }
 
 // Replace returns a InsertBuilder for this StatementBuilderType with the
 // statement keyword set to "REPLACE".
 func (b StatementBuilderType) Replace(into string) InsertBuilder {
-	return InsertBuilder(b).statementKeyword("REPLACE").Into(into)
+	return InsertBuilder{&insertData{statementOptions: b.options}}.statementKeyword("REPLACE").Into(into)
 }
 
 // Update returns a UpdateBuilder for this StatementBuilderType.
 func (b StatementBuilderType) Update(table string) UpdateBuilder {
-	return UpdateBuilder(b).Table(table)
+	return UpdateBuilder{&updateData{statementOptions: b.options, WhereParts: b.whereParts}}.Table(table)
 }
 
 // Delete returns a DeleteBuilder for this StatementBuilderType.
 func (b StatementBuilderType) Delete(from string) DeleteBuilder {
-	return DeleteBuilder(b).From(from)
+	return DeleteBuilder{&deleteData{statementOptions: b.options, WhereParts: b.whereParts}}.From(from)
 }
 
 // Create returns a CreateBuilder for this StatementBuilderType.
 func (b StatementBuilderType) Create(table string) CreateBuilder {
-	return CreateBuilder(b).Table(table)
+	return CreateBuilder{&createStmt{statementOptions: b.options}}.Table(table)
 }
 
 // Drop returns a DropBuilder for this StatementBuilderType.
 func (b StatementBuilderType) Drop(table string) DropBuilder {
-	return DropBuilder(b).Table(table)
+	return DropBuilder{&dropStmt{statementOptions: b.options}}.Table(table)
 }
 
 // PlaceholderFormat sets the PlaceholderFormat field for any child builders.
 func (b StatementBuilderType) PlaceholderFormat(f PlaceholderFormat) StatementBuilderType {
-	return builder.Set(b, "PlaceholderFormat", f).(StatementBuilderType)
+	b.options.PlaceholderFormat = f
+	return b
+}
+
+// ListParams sets the ListParams field for any child builders.
+//
+// When enabled, slices and arrays in Eq and NotEq predicates of WHERE and
+// HAVING clauses are bound as a single List<T> parameter ("id IN $p1")
+// instead of one parameter per item ("id IN ($p1,$p2,$p3)"), so the query
+// text stays the same for any number of items.
+//
+// See EqList for more information.
+func (b StatementBuilderType) ListParams(enabled bool) StatementBuilderType {
+	b.options.ListParams = enabled
+	return b
+}
+
+// LimitParams sets the LimitParams field for any child builders.
+//
+// When enabled, numbers set by Limit and Offset are bound as Uint64 parameters
+// ("LIMIT $p1 OFFSET $p2") instead of being written into the query, so
+// the query text stays the same for any page size and offset.
+// Use LimitExpr and OffsetExpr to set the clauses with expressions.
+func (b StatementBuilderType) LimitParams(enabled bool) StatementBuilderType {
+	b.options.LimitParams = enabled
+	return b
+}
+
+// IdentMode sets the IdentMode field for any child builders.
+//
+// The mode is applied to every table and column name of the statement: the
+// table of Insert, Update, Delete, Create and Drop, the From and View of Select
+// and Update, the columns of Insert, Create, Update.Set and Returning (except *)
+// and the keys of map predicates (Eq, Lt, Like etc.) in WHERE and HAVING clauses.
+// Select columns and string predicates are SQL expressions and are left as is.
+//
+// IdentQuote quotes names with backticks, so names with dashes, reserved words
+// and paths can be used without quoting by hand.
+// IdentValidate returns ErrInvalidIdent for names that are neither plain
+// nor quoted, use it when names may come from user input.
+func (b StatementBuilderType) IdentMode(mode IdentMode) StatementBuilderType {
+	b.options.IdentMode = mode
+	return b
+}
+
+// OmitDeclare sets the OmitDeclare field for any child builders.
+//
+// When enabled, ToYdbSql doesn't add "DECLARE $pN AS <Type>;" statements,
+// YDB Query Service infers the types of the params.
+//
+// DECLAREs written by hand (e.g. in Prefix) are kept in any case, params
+// declared this way aren't declared again. ToYdbSql returns ErrDuplicateDeclare
+// if a param is declared twice and ErrDeclareTypeMismatch if it's declared with
+// different types or with a type other than the type of the value.
+func (b StatementBuilderType) OmitDeclare(omit bool) StatementBuilderType {
+	b.options.OmitDeclare = omit
+	return b
+}
+
+// Limits sets the Limits field for any child builders.
+//
+// ToYdbSql returns *LimitError if the query exceeds the limits,
+// see InsertBuilder.Chunks to split large inserts.
+func (b StatementBuilderType) Limits(limits Limits) StatementBuilderType {
+	b.options.Limits = limits
+	return b
+}
+
+// SafeMode sets the SafeMode field for Update and Delete builders.
+//
+// In the safe mode, which is on in StatementBuilder, ToSql of Update and
+// Delete builders returns ErrUnfilteredStatement if the query has no WHERE
+// or only empty predicates, e.g. Where(Eq{}) built from an empty map.
+// Call AllRows to change all rows on purpose.
+func (b StatementBuilderType) SafeMode(enabled bool) StatementBuilderType {
+	b.options.SafeMode = enabled
+	return b
 }
 
 // RunWith sets the RunWith field for any child builders.
 func (b StatementBuilderType) RunWith(runner BaseRunner) StatementBuilderType {
-	return setRunWith(b, runner).(StatementBuilderType)
+	b.options.RunWith = wrapRunner(runner)
+	return b
 }
 
 // Where adds WHERE expressions to the query.
 //
+// The expressions are added to Select, Update and Delete builders.
+//
 // See SelectBuilder.Where for more information.
 func (b StatementBuilderType) Where(pred any, args ...any) StatementBuilderType {
-	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(StatementBuilderType)
+	b.whereParts = appendCopy(b.whereParts, newWherePart(pred, args...))
+	return b
 }
 
 // StatementBuilder is a parent builder for other builders, e.g. SelectBuilder.
-var StatementBuilder = StatementBuilderType(builder.EmptyBuilder).PlaceholderFormat(DollarP)
+var StatementBuilder = StatementBuilderType{}.PlaceholderFormat(DollarP).SafeMode(true)
 
 // Select returns a new SelectBuilder, optionally setting some result columns.
 //
@@ -114,7 +195,7 @@ func Drop(table string) DropBuilder {
 // Case returns a new CaseBuilder
 // "what" represents case value
 func Case(what ...any) CaseBuilder {
-	b := CaseBuilder(builder.EmptyBuilder)
+	b := CaseBuilder{}
 
 	switch len(what) {
 	case 0:
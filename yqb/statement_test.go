@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"testing"
 
-	"github.com/lann/builder"
 	"github.com/stretchr/testify/assert"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 )
@@ -28,10 +27,10 @@ func TestStatementBuilderPlaceholderFormat(t *testing.T) {
 func TestRunWithDB(t *testing.T) {
 	db := &sql.DB{}
 	assert.NotPanics(t, func() {
-		builder.GetStruct(Select().RunWith(db))
-		builder.GetStruct(Insert("t").RunWith(db))
-		builder.GetStruct(Update("t").RunWith(db))
-		builder.GetStruct(Delete("t").RunWith(db))
+		assert.IsType(t, &stdsqlRunner{}, Select().RunWith(db).data().RunWith)
+		assert.IsType(t, &stdsqlRunner{}, Insert("t").RunWith(db).data().RunWith)
+		assert.IsType(t, &stdsqlRunner{}, Update("t").RunWith(db).data().RunWith)
+		assert.IsType(t, &stdsqlRunner{}, Delete("t").RunWith(db).data().RunWith)
 	}, "RunWith(*sql.DB) should not panic")
 
 }
@@ -39,10 +38,10 @@ func TestRunWithDB(t *testing.T) {
 func TestRunWithTx(t *testing.T) {
 	tx := &sql.Tx{}
 	assert.NotPanics(t, func() {
-		builder.GetStruct(Select().RunWith(tx))
-		builder.GetStruct(Insert("t").RunWith(tx))
-		builder.GetStruct(Update("t").RunWith(tx))
-		builder.GetStruct(Delete("t").RunWith(tx))
+		assert.IsType(t, &stdsqlRunner{}, Select().RunWith(tx).data().RunWith)
+		assert.IsType(t, &stdsqlRunner{}, Insert("t").RunWith(tx).data().RunWith)
+		assert.IsType(t, &stdsqlRunner{}, Update("t").RunWith(tx).data().RunWith)
+		assert.IsType(t, &stdsqlRunner{}, Delete("t").RunWith(tx).data().RunWith)
 	}, "RunWith(*sql.Tx) should not panic")
 }
 
@@ -83,3 +82,54 @@ func TestStatementBuilderWhere(t *testing.T) {
 	expectedArgs := []any{argOne, argTwo}
 	assert.Equal(t, expectedArgs, args)
 }
+
+func TestStatementBuilderListParams(t *testing.T) {
+	sb := StatementBuilder.ListParams(true)
+
+	sql, args, err := sb.Select("test").
+		From("t").
+		Where(Eq{"a": []int{1, 2}}).
+		Where(Or{NotEq{"b": []int{3}}, Eq{"c": 4}}).
+		ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT test FROM t WHERE a IN $p1 AND (b NOT IN $p2 OR c = $p3)"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{
+		types.ListValue(types.Int64Value(1), types.Int64Value(2)),
+		types.ListValue(types.Int64Value(3)),
+		types.Int64Value(4),
+	}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, _, err = sb.Delete("t").Where(Eq{"a": []int{1, 2, 3}}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t WHERE a IN $p1", sql)
+
+	sql, _, err = sb.Select("test").ListParams(false).Where(Eq{"a": []int{1, 2}}).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT test WHERE a IN ($p1,$p2)", sql)
+}
+
+func TestStatementBuilderLimitParams(t *testing.T) {
+	sb := StatementBuilder.LimitParams(true)
+
+	sql, args, err := sb.Select("test").From("t").Where(Eq{"a": 1}).Limit(10).Offset(20).ToSql()
+	assert.NoError(t, err)
+
+	expectedSql := "SELECT test FROM t WHERE a = $p1 LIMIT $p2 OFFSET $p3"
+	assert.Equal(t, expectedSql, sql)
+
+	expectedArgs := []any{types.Int64Value(1), types.Uint64Value(10), types.Uint64Value(20)}
+	assert.Equal(t, expectedArgs, args)
+
+	sql, args, err = sb.Update("t").Set("a", 1).AllRows().Limit(5).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE t SET a = $p1 LIMIT $p2", sql)
+	assert.Equal(t, []any{types.Int64Value(1), types.Uint64Value(5)}, args)
+
+	sql, _, err = sb.Delete("t").AllRows().LimitParams(false).Limit(5).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE FROM t LIMIT 5", sql)
+}
@@ -7,22 +7,23 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/lann/builder"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
 type updateData struct {
-	PlaceholderFormat PlaceholderFormat
-	RunWith           BaseRunner
-	Prefixes          []Sqlizer
-	Table             string
-	SetClauses        []setClause
-	From              Sqlizer
-	WhereParts        []Sqlizer
-	OrderBys          []string
-	Limit             string
-	Offset            string
-	Suffixes          []Sqlizer
+	statementOptions
+
+	Prefixes   []Sqlizer
+	Table      string
+	SetClauses []setClause
+	From       Sqlizer
+	WhereParts []Sqlizer
+	OrderBys   []string
+	Limit      Sqlizer
+	Offset     Sqlizer
+	AllRows    bool
+	Returning  []string
+	Suffixes   []Sqlizer
 }
 
 type setClause struct {
@@ -74,6 +75,10 @@ func (d *updateData) ToSql() (sqlStr string, args []any, err error) {
 	return
 }
 
+func (d *updateData) predicateOptions() predicateOptions {
+	return predicateOptions{listParams: d.ListParams, identMode: d.IdentMode}
+}
+
 func (d *updateData) toSqlRaw() (sqlStr string, args []any, err error) {
 	if len(d.Table) == 0 {
 		err = fmt.Errorf("update statements must specify a table")
@@ -83,6 +88,11 @@ func (d *updateData) toSqlRaw() (sqlStr string, args []any, err error) {
 		err = fmt.Errorf("update statements must have at least one Set clause")
 		return
 	}
+	if d.SafeMode && !d.AllRows {
+		if err = checkFiltered(d.WhereParts); err != nil {
+			return
+		}
+	}
 
 	sql := &bytes.Buffer{}
 
@@ -95,8 +105,13 @@ func (d *updateData) toSqlRaw() (sqlStr string, args []any, err error) {
 		sql.WriteString(" ")
 	}
 
+	tableName, err := d.IdentMode.apply(d.Table)
+	if err != nil {
+		return
+	}
+
 	sql.WriteString("UPDATE ")
-	sql.WriteString(d.Table)
+	sql.WriteString(tableName)
 
 	sql.WriteString(" SET ")
 	setSqls := make([]string, len(d.SetClauses))
@@ -117,39 +132,75 @@ func (d *updateData) toSqlRaw() (sqlStr string, args []any, err error) {
 			valSql = "?"
 			args = append(args, setClause.value)
 		}
-		setSqls[i] = fmt.Sprintf("%s = %s", setClause.column, valSql)
+		column, err := d.IdentMode.apply(setClause.column)
+		if err != nil {
+			return "", nil, err
+		}
+		setSqls[i] = fmt.Sprintf("%s = %s", column, valSql)
 	}
 	sql.WriteString(strings.Join(setSqls, ", "))
 
 	if d.From != nil {
+		var from Sqlizer
+		from, err = d.IdentMode.applyPart(d.From)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" FROM ")
-		args, err = appendToSql([]Sqlizer{d.From}, sql, "", args)
+		args, err = appendToSql([]Sqlizer{from}, sql, "", args)
 		if err != nil {
 			return
 		}
 	}
 
 	if len(d.WhereParts) > 0 {
+		var whereParts []Sqlizer
+		whereParts, err = d.predicateOptions().applyAll(d.WhereParts)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" WHERE ")
-		args, err = appendToSql(d.WhereParts, sql, " AND ", args)
+		args, err = appendToSql(whereParts, sql, " AND ", args)
 		if err != nil {
 			return
 		}
 	}
 
 	if len(d.OrderBys) > 0 {
+		var orderBys []string
+		orderBys, err = d.IdentMode.applyOrders(d.OrderBys)
+		if err != nil {
+			return
+		}
+
 		sql.WriteString(" ORDER BY ")
-		sql.WriteString(strings.Join(d.OrderBys, ", "))
+		sql.WriteString(strings.Join(orderBys, ", "))
+	}
+
+	if d.Limit != nil {
+		args, err = appendLimitToSql(" LIMIT ", d.Limit, d.LimitParams, sql, args)
+		if err != nil {
+			return
+		}
 	}
 
-	if len(d.Limit) > 0 {
-		sql.WriteString(" LIMIT ")
-		sql.WriteString(d.Limit)
+	if d.Offset != nil {
+		args, err = appendLimitToSql(" OFFSET ", d.Offset, d.LimitParams, sql, args)
+		if err != nil {
+			return
+		}
 	}
 
-	if len(d.Offset) > 0 {
-		sql.WriteString(" OFFSET ")
-		sql.WriteString(d.Offset)
+	if len(d.Returning) > 0 {
+		var returning []string
+		returning, err = d.IdentMode.applyColumns(d.Returning)
+		if err != nil {
+			return
+		}
+		sql.WriteString(" RETURNING ")
+		sql.WriteString(strings.Join(returning, ", "))
 	}
 
 	if len(d.Suffixes) > 0 {
@@ -168,10 +219,12 @@ func (d *updateData) toSqlRaw() (sqlStr string, args []any, err error) {
 // Builder
 
 // UpdateBuilder builds SQL UPDATE statements.
-type UpdateBuilder builder.Builder
+type UpdateBuilder struct {
+	d *updateData
+}
 
-func init() {
-	builder.Register(UpdateBuilder{}, updateData{})
+func (b UpdateBuilder) data() *updateData {
+	return dataOf(b.d)
 }
 
 // Format methods
@@ -179,29 +232,91 @@ func init() {
 // PlaceholderFormat sets PlaceholderFormat (e.g. Question or Dollar) for the
 // query.
 func (b UpdateBuilder) PlaceholderFormat(f PlaceholderFormat) UpdateBuilder {
-	return builder.Set(b, "PlaceholderFormat", f).(UpdateBuilder)
+	d := clone(b.d)
+	d.PlaceholderFormat = f
+	return UpdateBuilder{d}
+}
+
+// ListParams sets whether slices in Eq and NotEq predicates are bound as
+// a single List<T> parameter.
+//
+// See StatementBuilderType.ListParams.
+func (b UpdateBuilder) ListParams(enabled bool) UpdateBuilder {
+	d := clone(b.d)
+	d.ListParams = enabled
+	return UpdateBuilder{d}
+}
+
+// LimitParams sets whether numbers of LIMIT and OFFSET clauses are bound as
+// Uint64 parameters.
+//
+// See StatementBuilderType.LimitParams.
+func (b UpdateBuilder) LimitParams(enabled bool) UpdateBuilder {
+	d := clone(b.d)
+	d.LimitParams = enabled
+	return UpdateBuilder{d}
+}
+
+// IdentMode sets IdentMode (e.g. IdentQuote or IdentValidate) for table and
+// column names of the query.
+//
+// See StatementBuilderType.IdentMode.
+func (b UpdateBuilder) IdentMode(mode IdentMode) UpdateBuilder {
+	d := clone(b.d)
+	d.IdentMode = mode
+	return UpdateBuilder{d}
+}
+
+// OmitDeclare sets whether ToYdbSql leaves out DECLARE statements of the params.
+//
+// See StatementBuilderType.OmitDeclare.
+func (b UpdateBuilder) OmitDeclare(omit bool) UpdateBuilder {
+	d := clone(b.d)
+	d.OmitDeclare = omit
+	return UpdateBuilder{d}
+}
+
+// Limits sets the limits of the query checked by ToYdbSql.
+//
+// See StatementBuilderType.Limits.
+func (b UpdateBuilder) Limits(limits Limits) UpdateBuilder {
+	d := clone(b.d)
+	d.Limits = limits
+	return UpdateBuilder{d}
+}
+
+// SafeMode sets whether ToSql returns ErrUnfilteredStatement for the query
+// without WHERE, unless AllRows is called.
+//
+// See StatementBuilderType.SafeMode.
+func (b UpdateBuilder) SafeMode(enabled bool) UpdateBuilder {
+	d := clone(b.d)
+	d.SafeMode = enabled
+	return UpdateBuilder{d}
 }
 
 // Runner methods
 
 // RunWith sets a Runner (like database/sql.DB) to be used with e.g. Exec.
 func (b UpdateBuilder) RunWith(runner BaseRunner) UpdateBuilder {
-	return setRunWith(b, runner).(UpdateBuilder)
+	d := clone(b.d)
+	d.RunWith = wrapRunner(runner)
+	return UpdateBuilder{d}
 }
 
 // Exec builds and Execs the query with the Runner set by RunWith.
 func (b UpdateBuilder) Exec() (sql.Result, error) {
-	data := builder.GetStruct(b).(updateData)
+	data := b.data()
 	return data.Exec()
 }
 
 func (b UpdateBuilder) Query() (*sql.Rows, error) {
-	data := builder.GetStruct(b).(updateData)
+	data := b.data()
 	return data.Query()
 }
 
 func (b UpdateBuilder) QueryRow() RowScanner {
-	data := builder.GetStruct(b).(updateData)
+	data := b.data()
 	return data.QueryRow()
 }
 
@@ -213,7 +328,7 @@ func (b UpdateBuilder) Scan(dest ...any) error {
 
 // ToSql builds the query into a SQL string and bound args.
 func (b UpdateBuilder) ToSql() (string, []any, error) {
-	data := builder.GetStruct(b).(updateData)
+	data := b.data()
 	return data.ToSql()
 }
 
@@ -224,7 +339,7 @@ func (b UpdateBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("b.ToSql: %w", err)
 	}
 
-	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args)
+	ydbSqlStr, err := prepareYdbSqlString(sqlStr, args, b.data().OmitDeclare)
 	if err != nil {
 		return sqlStr, nil, fmt.Errorf("prepareYdbSqlString: %w", err)
 	}
@@ -234,6 +349,10 @@ func (b UpdateBuilder) ToYdbSql() (string, []table.ParameterOption, error) {
 		return sqlStr, nil, fmt.Errorf("prepareYdbParams: %w", err)
 	}
 
+	if err = checkLimits(b.data().Limits, ydbSqlStr, ydbArgs); err != nil {
+		return sqlStr, nil, fmt.Errorf("checkLimits: %w", err)
+	}
+
 	return ydbSqlStr, ydbArgs, err
 }
 
@@ -244,17 +363,23 @@ func (b UpdateBuilder) Prefix(sql string, args ...any) UpdateBuilder {
 
 // PrefixExpr adds an expression to the very beginning of the query
 func (b UpdateBuilder) PrefixExpr(expr Sqlizer) UpdateBuilder {
-	return builder.Append(b, "Prefixes", expr).(UpdateBuilder)
+	d := clone(b.d)
+	d.Prefixes = appendCopy(d.Prefixes, expr)
+	return UpdateBuilder{d}
 }
 
 // Table sets the table to be updated.
 func (b UpdateBuilder) Table(table string) UpdateBuilder {
-	return builder.Set(b, "Table", table).(UpdateBuilder)
+	d := clone(b.d)
+	d.Table = table
+	return UpdateBuilder{d}
 }
 
 // Set adds SET clauses to the query.
 func (b UpdateBuilder) Set(column string, value any) UpdateBuilder {
-	return builder.Append(b, "SetClauses", setClause{column: column, value: value}).(UpdateBuilder)
+	d := clone(b.d)
+	d.SetClauses = appendCopy(d.SetClauses, setClause{column: column, value: value})
+	return UpdateBuilder{d}
 }
 
 // SetMap is a convenience method which calls .Set for each key/value pair in clauses.
@@ -276,36 +401,85 @@ func (b UpdateBuilder) SetMap(clauses map[string]any) UpdateBuilder {
 // From adds FROM clause to the query
 // FROM is valid construct in postgresql only.
 func (b UpdateBuilder) From(from string) UpdateBuilder {
-	return builder.Set(b, "From", newPart(from)).(UpdateBuilder)
+	d := clone(b.d)
+	d.From = newPart(from)
+	return UpdateBuilder{d}
 }
 
 // FromSelect sets a subquery into the FROM clause of the query.
 func (b UpdateBuilder) FromSelect(from SelectBuilder, alias string) UpdateBuilder {
 	// Prevent misnumbered parameters in nested selects (#183).
 	from = from.PlaceholderFormat(Question)
-	return builder.Set(b, "From", Alias(from, alias)).(UpdateBuilder)
+	d := clone(b.d)
+	d.From = Alias(from, alias)
+	return UpdateBuilder{d}
 }
 
 // Where adds WHERE expressions to the query.
 //
 // See SelectBuilder.Where for more information.
 func (b UpdateBuilder) Where(pred any, args ...any) UpdateBuilder {
-	return builder.Append(b, "WhereParts", newWherePart(pred, args...)).(UpdateBuilder)
+	d := clone(b.d)
+	d.WhereParts = appendCopy(d.WhereParts, newWherePart(pred, args...))
+	return UpdateBuilder{d}
+}
+
+// AllRows allows the query to update all rows without WHERE in the safe mode.
+//
+// See StatementBuilderType.SafeMode.
+func (b UpdateBuilder) AllRows() UpdateBuilder {
+	d := clone(b.d)
+	d.AllRows = true
+	return UpdateBuilder{d}
 }
 
 // OrderBy adds ORDER BY expressions to the query.
+//
+// The IdentMode of the builder is applied to their columns, e.g. "created_at DESC".
 func (b UpdateBuilder) OrderBy(orderBys ...string) UpdateBuilder {
-	return builder.Extend(b, "OrderBys", orderBys).(UpdateBuilder)
+	d := clone(b.d)
+	d.OrderBys = appendCopy(d.OrderBys, orderBys...)
+	return UpdateBuilder{d}
 }
 
 // Limit sets a LIMIT clause on the query.
 func (b UpdateBuilder) Limit(limit uint64) UpdateBuilder {
-	return builder.Set(b, "Limit", fmt.Sprintf("%d", limit)).(UpdateBuilder)
+	d := clone(b.d)
+	d.Limit = limitValue(limit)
+	return UpdateBuilder{d}
 }
 
 // Offset sets a OFFSET clause on the query.
 func (b UpdateBuilder) Offset(offset uint64) UpdateBuilder {
-	return builder.Set(b, "Offset", fmt.Sprintf("%d", offset)).(UpdateBuilder)
+	d := clone(b.d)
+	d.Offset = limitValue(offset)
+	return UpdateBuilder{d}
+}
+
+// LimitExpr sets a LIMIT clause on the query with an expression, e.g. Expr("$limit").
+func (b UpdateBuilder) LimitExpr(expr Sqlizer) UpdateBuilder {
+	d := clone(b.d)
+	d.Limit = expr
+	return UpdateBuilder{d}
+}
+
+// OffsetExpr sets a OFFSET clause on the query with an expression, e.g. Expr("$offset").
+func (b UpdateBuilder) OffsetExpr(expr Sqlizer) UpdateBuilder {
+	d := clone(b.d)
+	d.Offset = expr
+	return UpdateBuilder{d}
+}
+
+// Returning adds a RETURNING clause to the query, it returns the updated rows.
+// Use * to return all columns.
+// The rows can be scanned with GetX and SelectX of yqe.Executor.
+// Ex:
+//
+//	Update("users").Set("name", "a").Where(Eq{"id": 1}).Returning("id", "updated_at")
+func (b UpdateBuilder) Returning(columns ...string) UpdateBuilder {
+	d := clone(b.d)
+	d.Returning = appendCopy(d.Returning, columns...)
+	return UpdateBuilder{d}
 }
 
 // Suffix adds an expression to the end of the query
@@ -315,5 +489,7 @@ func (b UpdateBuilder) Suffix(sql string, args ...any) UpdateBuilder {
 
 // SuffixExpr adds an expression to the end of the query
 func (b UpdateBuilder) SuffixExpr(expr Sqlizer) UpdateBuilder {
-	return builder.Append(b, "Suffixes", expr).(UpdateBuilder)
+	d := clone(b.d)
+	d.Suffixes = appendCopy(d.Suffixes, expr)
+	return UpdateBuilder{d}
 }
@@ -59,7 +59,7 @@ func TestUpdateBuilderToSqlErr(t *testing.T) {
 }
 
 func TestUpdateBuilderPlaceholders(t *testing.T) {
-	b := Update("test").SetMap(Eq{"x": 1, "y": 2})
+	b := Update("test").SetMap(Eq{"x": 1, "y": 2}).AllRows()
 
 	sql, _, _ := b.PlaceholderFormat(Question).ToSql()
 	assert.Equal(t, "UPDATE test SET x = ?, y = ?", sql)
@@ -70,7 +70,7 @@ func TestUpdateBuilderPlaceholders(t *testing.T) {
 
 func TestUpdateBuilderRunners(t *testing.T) {
 	db := &DBStub{}
-	b := Update("test").Set("x", 1).RunWith(db)
+	b := Update("test").Set("x", 1).AllRows().RunWith(db)
 
 	expectedSql := "UPDATE test SET x = $p1"
 
@@ -110,3 +110,47 @@ func TestUpdateBuilderFromSelect(t *testing.T) {
 			"WHERE employees.account_id = subquery.id"
 	assert.Equal(t, expectedSql, sql)
 }
+
+func TestUpdateBuilderReturning(t *testing.T) {
+	b := StatementBuilder.IdentMode(IdentQuote).
+		Update("table").
+		Set("a", 1).
+		Where(Eq{"id": 2}).
+		Returning("*", "updated-at")
+
+	sql, args, err := b.ToSql()
+	assert.NoError(t, err)
+
+	expectedSQL := "UPDATE `table` SET `a` = $p1 WHERE `id` = $p2 RETURNING *, `updated-at`"
+	assert.Equal(t, expectedSQL, sql)
+
+	expectedArgs := []any{types.Int64Value(1), types.Int64Value(2)}
+	assert.Equal(t, expectedArgs, args)
+}
+
+func TestUpdateBuilderSafeMode(t *testing.T) {
+	_, _, err := Update("test").Set("x", 1).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	ids := map[string]any{}
+	_, _, err = Update("test").Set("x", 1).Where(Eq(ids)).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	_, _, err = Update("test").Set("x", 1).Where(And{Eq{}, Gt{}}).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	_, _, err = Update("test").Set("x", 1).Where(Or{Eq{"id": 1}, NotEq{"id": []int{}}}).ToSql()
+	assert.ErrorIs(t, err, ErrUnfilteredStatement)
+
+	sql, _, err := Update("test").Set("x", 1).Where(Eq{}).Where("y > ?", 2).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE test SET x = $p1 WHERE (1=1) AND y > $p2", sql)
+
+	sql, _, err = Update("test").Set("x", 1).AllRows().ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE test SET x = $p1", sql)
+
+	sql, _, err = StatementBuilder.SafeMode(false).Update("test").Set("x", 1).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE test SET x = $p1", sql)
+}
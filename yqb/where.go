@@ -1,9 +1,17 @@
 package yqb
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 )
 
+// ErrUnfilteredStatement is returned in the safe mode for UPDATE and DELETE
+// statements without WHERE, see StatementBuilderType.SafeMode.
+var ErrUnfilteredStatement = errors.New("UPDATE or DELETE without WHERE, use AllRows to change all rows")
+
 type wherePart part
 
 func newWherePart(pred any, args ...any) Sqlizer {
@@ -28,3 +36,196 @@ func (p wherePart) ToSql() (sql string, args []any, err error) {
 	}
 	return
 }
+
+// predicateOptions are statement options applied to WHERE and HAVING predicates,
+// see StatementBuilderType.ListParams and StatementBuilderType.IdentMode.
+type predicateOptions struct {
+	listParams bool
+	identMode  IdentMode
+}
+
+func (o predicateOptions) applyAll(parts []Sqlizer) ([]Sqlizer, error) {
+	if !o.listParams && o.identMode == IdentAsIs {
+		return parts, nil
+	}
+	applied := make([]Sqlizer, 0, len(parts))
+	for _, p := range parts {
+		ap, err := o.apply(p)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, ap)
+	}
+	return applied, nil
+}
+
+func (o predicateOptions) apply(p Sqlizer) (Sqlizer, error) {
+	switch pred := p.(type) {
+	case *wherePart:
+		var (
+			wherePred Sqlizer
+			err       error
+		)
+		switch pp := pred.pred.(type) {
+		case map[string]any:
+			wherePred, err = o.apply(Eq(pp))
+		case Sqlizer:
+			wherePred, err = o.apply(pp)
+		default:
+			return p, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &wherePart{pred: wherePred, args: pred.args}, nil
+	case Eq:
+		keys, err := o.identMode.applyKeys(pred)
+		if o.listParams {
+			return EqList(keys), err
+		}
+		return Eq(keys), err
+	case NotEq:
+		keys, err := o.identMode.applyKeys(pred)
+		if o.listParams {
+			return NotEqList(keys), err
+		}
+		return NotEq(keys), err
+	case EqList:
+		keys, err := o.identMode.applyKeys(pred)
+		return EqList(keys), err
+	case NotEqList:
+		keys, err := o.identMode.applyKeys(pred)
+		return NotEqList(keys), err
+	case Lt:
+		keys, err := o.identMode.applyKeys(pred)
+		return Lt(keys), err
+	case LtOrEq:
+		keys, err := o.identMode.applyKeys(pred)
+		return LtOrEq(keys), err
+	case Gt:
+		keys, err := o.identMode.applyKeys(pred)
+		return Gt(keys), err
+	case GtOrEq:
+		keys, err := o.identMode.applyKeys(pred)
+		return GtOrEq(keys), err
+	case Like:
+		keys, err := o.identMode.applyKeys(pred)
+		return Like(keys), err
+	case NotLike:
+		keys, err := o.identMode.applyKeys(pred)
+		return NotLike(keys), err
+	case ILike:
+		keys, err := o.identMode.applyKeys(pred)
+		return ILike(keys), err
+	case NotILike:
+		keys, err := o.identMode.applyKeys(pred)
+		return NotILike(keys), err
+	case StartsWith:
+		keys, err := o.identMode.applyKeys(pred)
+		return StartsWith(keys), err
+	case And:
+		parts, err := o.applyAll(pred)
+		return And(parts), err
+	case Or:
+		parts, err := o.applyAll(pred)
+		return Or(parts), err
+	}
+	return p, nil
+}
+
+// checkFiltered returns ErrUnfilteredStatement if the predicates don't filter
+// rows, i.e. there are none or all of them are empty like Eq{}.
+func checkFiltered(parts []Sqlizer) error {
+	for _, p := range parts {
+		if !isEmptyPredicate(p) {
+			return nil
+		}
+	}
+	return ErrUnfilteredStatement
+}
+
+// isEmptyPredicate reports whether the predicate is true for all rows
+// because it was built from an empty map or list, e.g. Eq{} or And{},
+// or it's NotEq{"id": []int{}} rendered as (1=1).
+func isEmptyPredicate(p Sqlizer) bool {
+	switch pred := p.(type) {
+	case *wherePart:
+		switch pp := pred.pred.(type) {
+		case nil:
+			return true
+		case map[string]any:
+			return len(pp) == 0
+		case Sqlizer:
+			return isEmptyPredicate(pp)
+		}
+	case Eq:
+		return len(pred) == 0
+	case NotEq:
+		return isEmptyNotEq(Eq(pred))
+	case EqList:
+		return len(pred) == 0
+	case NotEqList:
+		return isEmptyNotEq(Eq(pred))
+	case Lt:
+		return len(pred) == 0
+	case LtOrEq:
+		return len(pred) == 0
+	case Gt:
+		return len(pred) == 0
+	case GtOrEq:
+		return len(pred) == 0
+	case Like:
+		return len(pred) == 0
+	case NotLike:
+		return len(pred) == 0
+	case ILike:
+		return len(pred) == 0
+	case NotILike:
+		return len(pred) == 0
+	case StartsWith:
+		return len(pred) == 0
+	case And:
+		for _, c := range pred {
+			if !isEmptyPredicate(c) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, c := range pred {
+			if isEmptyPredicate(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isEmptyNotEq reports whether all values of NotEq are empty lists,
+// NOT IN an empty list is true for all rows.
+func isEmptyNotEq(neq Eq) bool {
+	for _, val := range neq {
+		if !isEmptyList(val) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyList reports whether Eq renders the value as an empty IN list.
+func isEmptyList(val any) bool {
+	if _, ok := val.(types.Value); ok {
+		return false
+	}
+	if _, ok := val.(SlotArg); ok {
+		return false
+	}
+	r := reflect.ValueOf(val)
+	if r.Kind() == reflect.Ptr {
+		if r.IsNil() {
+			return false
+		}
+		r = r.Elem()
+	}
+	return (r.Kind() == reflect.Slice || r.Kind() == reflect.Array) && r.Len() == 0 && isListType(r.Interface())
+}
@@ -0,0 +1,247 @@
+package yqe
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+const (
+	// DefaultBulkBatchBytes is the default size limit of a batch of BulkUpsert
+	DefaultBulkBatchBytes = 8 << 20
+	// DefaultBulkConcurrency is the default number of batches of BulkUpsert upserted at once
+	DefaultBulkConcurrency = 4
+)
+
+// BulkOption is an option of BulkUpsert, it's kept for the code written before TableOption
+//
+// Deprecated: use TableOption, the options are shared by BulkUpsert, ReadRows and ReadRange.
+type BulkOption = TableOption
+
+// BatchBytes sets the size limit of a batch, DefaultBulkBatchBytes by default
+//
+// The size of a row is estimated from its fields without building the value: the length
+// of the column names, strings and bytes and 8 bytes of other values. A row larger than
+// the limit is upserted in a batch of its own.
+func BatchBytes(n int) TableOption {
+	return func(o *tableOptions) {
+		if n > 0 {
+			o.batchBytes = n
+		}
+	}
+}
+
+// Concurrency sets the number of batches upserted at once, DefaultBulkConcurrency by default
+func Concurrency(n int) TableOption {
+	return func(o *tableOptions) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithBulkUpsertOptions sets the options of table.Session.BulkUpsert
+func WithBulkUpsertOptions(opts ...options.BulkUpsertOption) TableOption {
+	return func(o *tableOptions) {
+		o.upsertOpts = append(o.upsertOpts, opts...)
+	}
+}
+
+// BatchError is an error of a batch of BulkUpsert
+type BatchError struct {
+	// Offset is the index of the first row of the batch in the rows
+	Offset int
+	// Rows is the number of rows of the batch
+	Rows int
+	Err  error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch of rows [%d, %d): %v", e.Offset, e.Offset+e.Rows, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BulkUpsertError is returned by BulkUpsert if some batches are failed,
+// the other batches are upserted
+type BulkUpsertError struct {
+	// Batches are the errors of the failed batches in the order of the rows
+	Batches []*BatchError
+}
+
+func (e *BulkUpsertError) Error() string {
+	return fmt.Sprintf("%d batches failed, first: %v", len(e.Batches), e.Batches[0])
+}
+
+func (e *BulkUpsertError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Batches))
+	for _, b := range e.Batches {
+		errs = append(errs, b)
+	}
+	return errs
+}
+
+// bulkBatch is a batch of rows starting at offset
+type bulkBatch struct {
+	offset int
+	rows   []types.Value
+}
+
+// BulkUpsert upserts the rows into the table with table.Session.BulkUpsert, the path of
+// the table must be full, e.g. path.Join(db.Name(), "users")
+//
+// The columns are the fields of T by the db tag like in yscan. The column types are
+// the YQL types of the ydb tag, e.g. `ydb:"Date"`, the types of the table description,
+// see WithTableDescription and DescribeTable, or inferred from the Go types.
+//
+// The rows are upserted in batches limited by BatchBytes, Concurrency batches at once.
+// Every batch is retried in table.Client.Do, the errors of failed batches are returned
+// as *BulkUpsertError. No more batches are started after ctx is done, the rows of them
+// are returned as one *BatchError with the error of ctx.
+// Ex:
+//
+//	type User struct {
+//		ID        uint64    `db:"id"`
+//		Name      string    `db:"name"`
+//		CreatedAt time.Time `db:"created_at" ydb:"Date"`
+//	}
+//
+//	err := yqe.BulkUpsert(ctx, db.Table(), path.Join(db.Name(), "users"), users)
+func BulkUpsert[T any](ctx context.Context, client table.Client, path string, rows []T, opts ...TableOption) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	o := applyTableOptions(opts)
+	if err := o.describeTable(ctx, client, path); err != nil {
+		return err
+	}
+
+	batches, err := bulkBatches(rows, columnTypes(o.desc), o.batchBytes)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, o.concurrency)
+		failed = make([]*BatchError, len(batches)+1)
+	)
+	for i, batch := range batches {
+		if !acquire(ctx, sem) {
+			failed[len(batches)] = &BatchError{Offset: batch.offset, Rows: len(rows) - batch.offset, Err: ctx.Err()}
+			break
+		}
+		wg.Add(1)
+		go func(i int, batch bulkBatch) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := client.Do(ctx, func(ctx context.Context, s table.Session) error {
+				return s.BulkUpsert(ctx, path, types.ListValue(batch.rows...), o.upsertOpts...)
+			}, table.WithIdempotent())
+			if err != nil {
+				failed[i] = &BatchError{Offset: batch.offset, Rows: len(batch.rows), Err: fmt.Errorf("client.Do: %w", err)}
+			}
+		}(i, batch)
+	}
+	wg.Wait()
+
+	bulkErr := &BulkUpsertError{}
+	for _, batchErr := range failed {
+		if batchErr != nil {
+			bulkErr.Batches = append(bulkErr.Batches, batchErr)
+		}
+	}
+	if len(bulkErr.Batches) > 0 {
+		return bulkErr
+	}
+	return nil
+}
+
+// acquire takes a slot of the semaphore, it returns false if ctx is done
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// bulkBatches converts the rows to YDB struct values and splits them into batches of batchBytes
+func bulkBatches[T any](rows []T, tableTypes map[string]types.Type, batchBytes int) ([]bulkBatch, error) {
+	columns, err := structColumnsOf[T]("row")
+	if err != nil {
+		return nil, err
+	}
+	if err = checkColumns(columns, tableTypes); err != nil {
+		return nil, err
+	}
+
+	var (
+		batches []bulkBatch
+		batch   bulkBatch
+		size    int
+	)
+	for i := range rows {
+		row, err := structValue(reflect.ValueOf(&rows[i]).Elem(), columns, tableTypes)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+
+		rowSize := estimateSize(reflect.ValueOf(&rows[i]).Elem(), columns)
+		if len(batch.rows) > 0 && size+rowSize > batchBytes {
+			batches = append(batches, batch)
+			batch, size = bulkBatch{offset: i}, 0
+		}
+		batch.rows = append(batch.rows, row)
+		size += rowSize
+	}
+	return append(batches, batch), nil
+}
+
+// estimateSize estimates the size of the row of the struct v, see BatchBytes
+func estimateSize(v reflect.Value, columns []structColumn) int {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	size := 0
+	for _, c := range columns {
+		field, ok := c.field(v)
+		if !ok {
+			size += len(c.name) + 1
+			continue
+		}
+		size += len(c.name) + estimateValueSize(field)
+	}
+	return size
+}
+
+func estimateValueSize(v reflect.Value) int {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 1
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		return v.Len()
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.Uint8:
+		return v.Len()
+	}
+	return 8
+}
@@ -0,0 +1,164 @@
+package yqe
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+type tableSessionStub struct {
+	table.Session
+	c *tableClientStub
+}
+
+func (s tableSessionStub) DescribeTable(context.Context, string, ...options.DescribeTableOption) (options.Description, error) {
+	return s.c.desc, nil
+}
+
+func (s tableSessionStub) BulkUpsert(_ context.Context, path string, rows types.Value, _ ...options.BulkUpsertOption) error {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	if s.c.fail != "" && strings.Contains(rows.Yql(), s.c.fail) {
+		return errors.New("bulk upsert failed")
+	}
+	s.c.path = path
+	s.c.batches = append(s.c.batches, rows.Yql())
+	return nil
+}
+
+type tableClientStub struct {
+	table.Client
+	mu       sync.Mutex
+	desc     options.Description
+	fail     string
+	path     string
+	batches  []string
+	keys     string
+	result   *resultStub
+	readOpts []options.ReadTableOption
+}
+
+func (c *tableClientStub) Do(ctx context.Context, op table.Operation, _ ...table.Option) error {
+	return op(ctx, tableSessionStub{c: c})
+}
+
+type bulkRow struct {
+	ID        uint64    `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at" ydb:"Date"`
+	Score     *float64  `db:"score"`
+	Skipped   string    `db:"-"`
+}
+
+func TestBulkUpsert(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	score := 1.5
+	rows := []bulkRow{
+		{ID: 1, Name: "a", CreatedAt: date, Score: &score},
+		{ID: 2, Name: "b", CreatedAt: date},
+		{ID: 3, Name: "c", CreatedAt: date},
+	}
+
+	stub := &tableClientStub{}
+	err := BulkUpsert(ctx, stub, "/local/users", rows)
+	assert.NoError(t, err)
+	assert.Equal(t, "/local/users", stub.path)
+	assert.Equal(t, []string{
+		"[<|`created_at`:Date(\"2023-01-02\"),`id`:1ul,`name`:\"a\"u,`score`:Just(Double(\"1.5\"))|>," +
+			"<|`created_at`:Date(\"2023-01-02\"),`id`:2ul,`name`:\"b\"u,`score`:Nothing(Optional<Double>)|>," +
+			"<|`created_at`:Date(\"2023-01-02\"),`id`:3ul,`name`:\"c\"u,`score`:Nothing(Optional<Double>)|>]",
+	}, stub.batches)
+
+	stub = &tableClientStub{desc: options.Description{Columns: []options.Column{
+		{Name: "id", Type: types.TypeUint32},
+		{Name: "name", Type: types.Optional(types.TypeBytes)},
+		{Name: "created_at", Type: types.TypeTimestamp},
+		{Name: "score", Type: types.Optional(types.TypeFloat)},
+	}}}
+	err = BulkUpsert(ctx, stub, "/local/users", rows[:1], DescribeTable())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"[<|`created_at`:Date(\"2023-01-02\"),`id`:1u,`name`:Just(\"a\"),`score`:Just(Float(\"1.5\"))|>]",
+	}, stub.batches)
+
+	err = BulkUpsert(ctx, stub, "/local/users", []struct {
+		ID    uint64 `db:"id"`
+		Email string `db:"email"`
+	}{{ID: 1}}, DescribeTable())
+	assert.EqualError(t, err, `column "email" isn't in the table`)
+}
+
+type BulkAudit struct {
+	UpdatedBy *string `db:"updated_by"`
+	Version   uint64  `db:"version" ydb:"Optional<Uint64>"`
+}
+
+func TestBulkUpsertEmbeddedPointer(t *testing.T) {
+	ctx := context.Background()
+	by := "a"
+	type row struct {
+		ID uint64 `db:"id"`
+		*BulkAudit
+	}
+
+	stub := &tableClientStub{}
+	err := BulkUpsert(ctx, stub, "/local/users", []row{{ID: 1, BulkAudit: &BulkAudit{UpdatedBy: &by, Version: 2}}, {ID: 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"[<|`id`:1ul,`updated_by`:Just(\"a\"u),`version`:Just(2ul)|>," +
+			"<|`id`:2ul,`updated_by`:Nothing(Optional<Utf8>),`version`:Nothing(Optional<Uint64>)|>]",
+	}, stub.batches)
+
+	err = BulkUpsert(ctx, stub, "/local/users", []struct {
+		Key uint64 `db:"key"`
+		*PageRow
+	}{{Key: 1}})
+	assert.EqualError(t, err, `row 0: column "id": nil value of Int64`)
+}
+
+func TestBulkUpsertBatches(t *testing.T) {
+	ctx := context.Background()
+	rows := make([]bulkRow, 10)
+	for i := range rows {
+		rows[i] = bulkRow{ID: uint64(i), Name: strings.Repeat("x", 10)}
+	}
+
+	stub := &tableClientStub{fail: "`id`:4ul"}
+	// the estimated size of a row is 48 bytes
+	opts := []BulkOption{BatchBytes(150), Concurrency(2)}
+	err := BulkUpsert(ctx, stub, "/local/users", rows, opts...)
+
+	var bulkErr *BulkUpsertError
+	assert.ErrorAs(t, err, &bulkErr)
+	assert.Len(t, bulkErr.Batches, 1)
+	assert.Equal(t, 3, bulkErr.Batches[0].Offset)
+	assert.Equal(t, 3, bulkErr.Batches[0].Rows)
+	assert.EqualError(t, bulkErr.Batches[0], "batch of rows [3, 6): client.Do: bulk upsert failed")
+
+	assert.Len(t, stub.batches, 3)
+}
+
+func TestBulkUpsertCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rows := make([]bulkRow, 10)
+
+	stub := &tableClientStub{}
+	err := BulkUpsert(ctx, stub, "/local/users", rows, BatchBytes(100), Concurrency(1))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var bulkErr *BulkUpsertError
+	assert.ErrorAs(t, err, &bulkErr)
+	assert.Len(t, bulkErr.Batches, 1)
+	assert.EqualError(t, bulkErr.Batches[0], "batch of rows [0, 10): context canceled")
+	assert.Empty(t, stub.batches)
+}
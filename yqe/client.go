@@ -0,0 +1,290 @@
+package yqe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// Client runs queries in sessions of table.Client with retries
+type Client struct {
+	client table.Client
+	opts   []Option
+}
+
+// New returns a Client running every query in table.Client.Do, e.g. yqe.New(db.Table())
+//
+// The options are applied to every call before the options of the call.
+func New(client table.Client, opts ...Option) *Client {
+	return &Client{
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Option is an option of Client calls
+type Option func(o *callOptions)
+
+type callOptions struct {
+	txc        *table.TransactionControl
+	autoTxc    bool
+	idempotent bool
+	commit     bool
+	pagination *pagination
+	execOpts   []ExecuteOption
+}
+
+// Idempotent marks the query as safe to repeat, so it's retried on any retryable error,
+// e.g. UPSERT of the same values or DELETE by primary key.
+//
+// Read-only queries are retried this way without the option, a query is read-only if
+// all statements of its full text are reads, see yqb.Describe and yqb.StatementKindOf.
+func Idempotent() Option {
+	return func(o *callOptions) {
+		o.idempotent = true
+	}
+}
+
+// TxControl sets table.TransactionControl of the query, table.DefaultTxControl by default,
+// see SerializableRW and other presets
+//
+// The transaction must be committed by the query, e.g. with table.CommitTx.
+func TxControl(txc *table.TransactionControl) Option {
+	return func(o *callOptions) {
+		if txc != nil {
+			o.txc = txc
+			o.autoTxc = false
+		}
+	}
+}
+
+// AutoTxControl chooses table.TransactionControl of the query by the statement kind:
+// OnlineRO for read-only queries, e.g. SelectBuilder, and SerializableRW for others,
+// including unknown and mixed read and write statements
+//
+// The kind of builders is detected by yqb.Describe, the kind of SQL strings by yqb.StatementKindOf.
+func AutoTxControl() Option {
+	return func(o *callOptions) {
+		o.autoTxc = true
+	}
+}
+
+// CommitTx commits the transaction of Tx with the statement, it saves the round trip of
+// the commit after the last statement, see InTx
+func CommitTx() Option {
+	return func(o *callOptions) {
+		o.commit = true
+	}
+}
+
+// WithExecuteOptions sets ExecuteOption of the query, e.g. OperationTimeout or CollectStats
+func WithExecuteOptions(opts ...ExecuteOption) Option {
+	return func(o *callOptions) {
+		o.execOpts = append(o.execOpts, opts...)
+	}
+}
+
+// applyOptions applies the option sets in order
+func applyOptions(optSets ...[]Option) callOptions {
+	o := callOptions{
+		txc: table.DefaultTxControl(),
+	}
+	for _, opts := range optSets {
+		for _, opt := range opts {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// do runs f with the Executor of a session in table.Client.Do
+func (c *Client) do(ctx context.Context, kind yqb.StatementKind, opts []Option, f func(ctx context.Context, e *Executor) error) error {
+	o := applyOptions(c.opts, opts)
+
+	txc := o.txc
+	if o.autoTxc {
+		txc = txControlOf(kind)
+	}
+
+	var doOpts []table.Option
+	if o.idempotent || kind == yqb.StatementRead {
+		doOpts = append(doOpts, table.WithIdempotent())
+	}
+
+	err := c.client.Do(ctx, func(ctx context.Context, s table.Session) error {
+		return f(ctx, UseSession(s).WithTxControl(txc).WithExecuteOptions(o.execOpts...))
+	}, doOpts...)
+	if err != nil {
+		return fmt.Errorf("client.Do: %w", err)
+	}
+	return nil
+}
+
+// Exec accepting SQL string and parameters
+func (c *Client) Exec(ctx context.Context, query string, params []table.ParameterOption, opts ...Option) (result.Result, error) {
+	return c.exec(ctx, yqb.StatementKindOf(query), query, params, opts)
+}
+
+// ExecX exec accepting SQL builder
+func (c *Client) ExecX(ctx context.Context, query yqb.YdbSqlizer, opts ...Option) (result.Result, error) {
+	info, err := yqb.Describe(query)
+	if err != nil {
+		return nil, fmt.Errorf("yqb.Describe: %w", err)
+	}
+
+	return c.exec(ctx, info.Kind, info.Query, info.Params, opts)
+}
+
+func (c *Client) exec(ctx context.Context, kind yqb.StatementKind, query string, params []table.ParameterOption, opts []Option) (result.Result, error) {
+	var res result.Result
+	err := c.do(ctx, kind, opts, func(ctx context.Context, e *Executor) (err error) {
+		_, res, err = e.Exec(ctx, query, params)
+		return err
+	})
+	return res, err
+}
+
+// Get accepting SQL string and parameters
+func (c *Client) Get(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...Option) error {
+	return c.get(ctx, yqb.StatementKindOf(query), dst, query, params, opts)
+}
+
+// GetX get accepting SQL builder
+func (c *Client) GetX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...Option) error {
+	info, err := yqb.Describe(query)
+	if err != nil {
+		return fmt.Errorf("yqb.Describe: %w", err)
+	}
+
+	return c.get(ctx, info.Kind, dst, info.Query, info.Params, opts)
+}
+
+func (c *Client) get(ctx context.Context, kind yqb.StatementKind, dst any, query string, params []table.ParameterOption, opts []Option) error {
+	return c.do(ctx, kind, opts, func(ctx context.Context, e *Executor) error {
+		_, err := e.Get(ctx, dst, query, params)
+		return err
+	})
+}
+
+// Select accepting SQL string and parameters
+func (c *Client) Select(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...Option) error {
+	return c.selectAll(ctx, yqb.StatementKindOf(query), dst, query, params, opts)
+}
+
+// SelectX select accepting SQL builder
+//
+// With Paginate the query must be yqb.SelectBuilder, the pages are read in one snapshot
+// read-only transaction.
+func (c *Client) SelectX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...Option) error {
+	if p := applyOptions(c.opts, opts).pagination; p != nil {
+		sb, ok := query.(yqb.SelectBuilder)
+		if !ok {
+			return fmt.Errorf("pagination of %T isn't supported", query)
+		}
+		return c.selectPages(ctx, dst, sb, *p, opts)
+	}
+
+	return c.selectX(ctx, dst, query, opts)
+}
+
+// selectPages reads the pages of the query in a snapshot read-only transaction in table.Client.DoTx
+func (c *Client) selectPages(ctx context.Context, dst any, query yqb.SelectBuilder, p pagination, opts []Option) error {
+	rows, err := sliceOf(dst)
+	if err != nil {
+		return err
+	}
+	n := rows.Len()
+
+	o := applyOptions(c.opts, opts)
+	err = c.client.DoTx(ctx, func(ctx context.Context, tx table.TransactionActor) error {
+		rows.Set(rows.Slice(0, n))
+		e := UseTransaction(tx).WithExecuteOptions(o.execOpts...)
+		return selectPages(dst, query, p, func(page any, query yqb.SelectBuilder) error {
+			_, err := e.SelectX(ctx, page, query)
+			return err
+		})
+	}, table.WithIdempotent(), table.WithTxSettings(table.TxSettings(table.WithSnapshotReadOnly())))
+	if err != nil {
+		return fmt.Errorf("client.DoTx: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) selectX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts []Option) error {
+	info, err := yqb.Describe(query)
+	if err != nil {
+		return fmt.Errorf("yqb.Describe: %w", err)
+	}
+
+	return c.selectAll(ctx, info.Kind, dst, info.Query, info.Params, opts)
+}
+
+func (c *Client) selectAll(ctx context.Context, kind yqb.StatementKind, dst any, query string, params []table.ParameterOption, opts []Option) error {
+	return c.do(ctx, kind, opts, func(ctx context.Context, e *Executor) error {
+		_, err := e.Select(ctx, dst, query, params)
+		return err
+	})
+}
+
+// SelectMulti accepting SQL string and parameters, see Executor.SelectMulti
+func (c *Client) SelectMulti(ctx context.Context, query string, params []table.ParameterOption, dsts []any, opts ...Option) error {
+	return c.selectMulti(ctx, yqb.StatementKindOf(query), query, params, dsts, opts)
+}
+
+// SelectMultiX select multi accepting SQL builder, e.g. yqb.Multi
+func (c *Client) SelectMultiX(ctx context.Context, query yqb.YdbSqlizer, dsts []any, opts ...Option) error {
+	info, err := yqb.Describe(query)
+	if err != nil {
+		return fmt.Errorf("yqb.Describe: %w", err)
+	}
+
+	return c.selectMulti(ctx, info.Kind, info.Query, info.Params, dsts, opts)
+}
+
+func (c *Client) selectMulti(ctx context.Context, kind yqb.StatementKind, query string, params []table.ParameterOption, dsts []any, opts []Option) error {
+	return c.do(ctx, kind, opts, func(ctx context.Context, e *Executor) error {
+		_, err := e.SelectMulti(ctx, query, params, dsts)
+		return err
+	})
+}
+
+// ExecScheme accepting SQL string, see Executor.ExecScheme
+func (c *Client) ExecScheme(ctx context.Context, query string, opts ...Option) error {
+	return c.do(ctx, yqb.StatementDDL, opts, func(ctx context.Context, e *Executor) error {
+		return e.ExecScheme(ctx, query)
+	})
+}
+
+// ExecSchemeX exec scheme accepting SQL builder, see Executor.ExecSchemeX
+func (c *Client) ExecSchemeX(ctx context.Context, query yqb.YdbSqlizer, opts ...Option) error {
+	yql, err := schemeYql(query)
+	if err != nil {
+		return err
+	}
+
+	return c.ExecScheme(ctx, yql, opts...)
+}
+
+// DoTx runs f with the Executor of a transaction in table.Client.DoTx
+//
+// The transaction is committed if f returns nil. Without Idempotent f is retried
+// only on errors that surely happened before the transaction was applied.
+func (c *Client) DoTx(ctx context.Context, f func(ctx context.Context, e *Executor) error, opts ...Option) error {
+	o := applyOptions(c.opts, opts)
+
+	var doOpts []table.Option
+	if o.idempotent {
+		doOpts = append(doOpts, table.WithIdempotent())
+	}
+
+	err := c.client.DoTx(ctx, func(ctx context.Context, tx table.TransactionActor) error {
+		return f(ctx, UseTransaction(tx).WithExecuteOptions(o.execOpts...))
+	}, doOpts...)
+	if err != nil {
+		return fmt.Errorf("client.DoTx: %w", err)
+	}
+	return nil
+}
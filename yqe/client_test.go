@@ -0,0 +1,169 @@
+package yqe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+type txStub struct {
+	table.Transaction
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *txStub) ID() string {
+	return "tx-1"
+}
+
+func (tx *txStub) CommitTx(context.Context, ...options.CommitTransactionOption) (result.Result, error) {
+	tx.committed = true
+	return nil, nil
+}
+
+func (tx *txStub) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+type sessionStub struct {
+	table.Session
+	ctx    context.Context
+	txc    *table.TransactionControl
+	query  string
+	params *table.QueryParameters
+	opts   []options.ExecuteDataQueryOption
+	tx     *txStub
+	result *resultStub
+	err    error
+}
+
+func (s *sessionStub) Execute(
+	ctx context.Context,
+	txc *table.TransactionControl,
+	query string,
+	params *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (table.Transaction, result.Result, error) {
+	s.ctx, s.txc, s.query, s.params, s.opts = ctx, txc, query, params, opts
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if s.tx == nil {
+		s.tx = &txStub{}
+	}
+	if s.result == nil {
+		return s.tx, nil, nil
+	}
+	return s.tx, s.result, nil
+}
+
+func (s *sessionStub) ExecuteSchemeQuery(_ context.Context, query string, _ ...options.ExecuteSchemeQueryOption) error {
+	s.query = query
+	return s.err
+}
+
+func (s *sessionStub) StreamExecuteScanQuery(
+	_ context.Context,
+	query string,
+	params *table.QueryParameters,
+	_ ...options.ExecuteScanQueryOption,
+) (result.StreamResult, error) {
+	s.query, s.params = query, params
+	return s.result, s.err
+}
+
+type clientStub struct {
+	table.Client
+	session *sessionStub
+	options table.Options
+	results []*resultStub
+	queries []string
+}
+
+func (c *clientStub) Do(ctx context.Context, op table.Operation, opts ...table.Option) error {
+	c.options = table.Options{}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	c.session = &sessionStub{}
+	if len(c.results) > 0 {
+		c.session.result, c.results = c.results[0], c.results[1:]
+	}
+	err := op(ctx, c.session)
+	c.queries = append(c.queries, c.session.query)
+	return err
+}
+
+func (c *clientStub) DoTx(ctx context.Context, op table.TxOperation, opts ...table.Option) error {
+	c.options = table.Options{}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return op(ctx, &txQueueStub{c: c})
+}
+
+// txQueueStub returns the results of clientStub in order
+type txQueueStub struct {
+	table.TransactionActor
+	c *clientStub
+}
+
+func (tx *txQueueStub) Execute(
+	_ context.Context,
+	query string,
+	_ *table.QueryParameters,
+	_ ...options.ExecuteDataQueryOption,
+) (result.Result, error) {
+	tx.c.queries = append(tx.c.queries, query)
+	if len(tx.c.results) == 0 {
+		return nil, errors.New("no results")
+	}
+	res := tx.c.results[0]
+	tx.c.results = tx.c.results[1:]
+	return res, nil
+}
+
+func TestClientExec(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+	c := New(stub)
+
+	_, err := c.ExecX(ctx, yqb.Select("id").From("users").Where(yqb.Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.True(t, stub.options.Idempotent)
+	assert.Equal(t, table.DefaultTxControl(), stub.session.txc)
+	assert.Equal(t, "DECLARE $p1 AS Int64;\nSELECT id FROM users WHERE id = $p1", stub.session.query)
+	assert.Equal(t, 1, stub.session.params.Count())
+
+	_, err = c.ExecX(ctx, yqb.Delete("users").Where(yqb.Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.False(t, stub.options.Idempotent)
+
+	_, err = c.Exec(ctx, "SELECT * FROM users; DELETE FROM users WHERE id = 1", nil)
+	assert.NoError(t, err)
+	assert.False(t, stub.options.Idempotent)
+
+	_, err = c.ExecX(ctx, yqb.Select("id").Prefix("DELETE FROM users WHERE id = 1;").From("users"))
+	assert.NoError(t, err)
+	assert.False(t, stub.options.Idempotent)
+
+	_, err = c.ExecX(ctx, yqb.Select("id").From("users").Suffix("; UPSERT INTO logs (id) VALUES (1)"))
+	assert.NoError(t, err)
+	assert.False(t, stub.options.Idempotent)
+
+	txc := table.TxControl(table.BeginTx(table.WithSerializableReadWrite()), table.CommitTx())
+	_, err = c.Exec(ctx, "DELETE FROM users", nil, Idempotent(), TxControl(txc))
+	assert.NoError(t, err)
+	assert.True(t, stub.options.Idempotent)
+	assert.Same(t, txc, stub.session.txc)
+
+	_, err = c.ExecX(ctx, yqb.Delete("users"))
+	assert.ErrorIs(t, err, yqb.ErrUnfilteredStatement)
+}
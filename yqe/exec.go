@@ -10,7 +10,7 @@ import (
 )
 
 // Exec accepting SQL string and parameters
-func (e *Executor) Exec(ctx context.Context, query string, params []table.ParameterOption) (table.Transaction, result.Result, error) {
+func (e *Executor) Exec(ctx context.Context, query string, params []table.ParameterOption, opts ...ExecuteOption) (table.Transaction, result.Result, error) {
 	var (
 		tx  table.Transaction
 		res result.Result
@@ -18,14 +18,14 @@ func (e *Executor) Exec(ctx context.Context, query string, params []table.Parame
 	)
 	switch {
 	case e.sessionRunner != nil:
-		tx, res, err = e.sessionRunner.Execute(ctx, e.txc, query, table.NewQueryParameters(
+		tx, res, err = e.session(opts).Execute(ctx, e.txControl(query), query, table.NewQueryParameters(
 			params...,
 		))
 		if err != nil {
 			return tx, res, fmt.Errorf("sessionRunner.Execute: %w", err)
 		}
 	case e.transactionRunner != nil:
-		res, err = e.transactionRunner.Execute(ctx, query, table.NewQueryParameters(
+		res, err = e.transaction(opts).Execute(ctx, query, table.NewQueryParameters(
 			params...,
 		))
 		tx, _ = e.transactionRunner.(table.Transaction)
@@ -40,11 +40,11 @@ func (e *Executor) Exec(ctx context.Context, query string, params []table.Parame
 }
 
 // ExecX select accepting SQL builder
-func (e *Executor) ExecX(ctx context.Context, query yqb.YdbSqlizer) (table.Transaction, result.Result, error) {
+func (e *Executor) ExecX(ctx context.Context, query yqb.YdbSqlizer, opts ...ExecuteOption) (table.Transaction, result.Result, error) {
 	ydbSqlStr, ydbParams, err := query.ToYdbSql()
 	if err != nil {
 		return nil, nil, fmt.Errorf("query.ToYdbSql: %w", err)
 	}
 
-	return e.Exec(ctx, ydbSqlStr, ydbParams)
+	return e.Exec(ctx, ydbSqlStr, ydbParams, opts...)
 }
@@ -0,0 +1,130 @@
+package yqe
+
+import (
+	"context"
+	"time"
+
+	"github.com/flymedllva/ydb-go-qb/yscan"
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+)
+
+// ExecuteOption is an option of the execution of a data query by Exec, Get and Select
+type ExecuteOption func(o *executeOptions)
+
+type executeOptions struct {
+	dataQueryOpts []options.ExecuteDataQueryOption
+	timeout       time.Duration
+	cancelAfter   time.Duration
+	stats         *stats.QueryStats
+}
+
+// KeepInCache keeps the compiled query in the server cache, it's enabled by default for queries with parameters
+func KeepInCache(keep bool) ExecuteOption {
+	return func(o *executeOptions) {
+		o.dataQueryOpts = append(o.dataQueryOpts, options.WithKeepInCache(keep))
+	}
+}
+
+// CollectStats collects the basic statistics of the query into dst, e.g. the rows read by the query
+func CollectStats(dst *stats.QueryStats) ExecuteOption {
+	return func(o *executeOptions) {
+		o.dataQueryOpts = append(o.dataQueryOpts, options.WithCollectStatsModeBasic())
+		o.stats = dst
+	}
+}
+
+// OperationTimeout sets the timeout of the query on the server, the query is cancelled
+// and its transaction is rolled back after it, see ydb.WithOperationTimeout
+func OperationTimeout(timeout time.Duration) ExecuteOption {
+	return func(o *executeOptions) {
+		o.timeout = timeout
+	}
+}
+
+// CancelAfter sets the time after which the query is cancelled on the server,
+// the changes of the query aren't rolled back, see ydb.WithOperationCancelAfter
+func CancelAfter(cancelAfter time.Duration) ExecuteOption {
+	return func(o *executeOptions) {
+		o.cancelAfter = cancelAfter
+	}
+}
+
+// WithDataQueryOptions sets other options of the data query, e.g. options.WithQueryCachePolicy
+func WithDataQueryOptions(opts ...options.ExecuteDataQueryOption) ExecuteOption {
+	return func(o *executeOptions) {
+		o.dataQueryOpts = append(o.dataQueryOpts, opts...)
+	}
+}
+
+// applyExecuteOptions applies the option sets in order
+func applyExecuteOptions(optSets ...[]ExecuteOption) executeOptions {
+	var o executeOptions
+	for _, opts := range optSets {
+		for _, opt := range opts {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// context returns the context with the operation params of the options
+func (o executeOptions) context(ctx context.Context) context.Context {
+	if o.timeout > 0 {
+		ctx = ydb.WithOperationTimeout(ctx, o.timeout)
+	}
+	if o.cancelAfter > 0 {
+		ctx = ydb.WithOperationCancelAfter(ctx, o.cancelAfter)
+	}
+	return ctx
+}
+
+// options returns the data query options followed by the opts
+func (o executeOptions) options(opts []options.ExecuteDataQueryOption) []options.ExecuteDataQueryOption {
+	return append(o.dataQueryOpts[:len(o.dataQueryOpts):len(o.dataQueryOpts)], opts...)
+}
+
+// done saves the statistics of the result for CollectStats
+func (o executeOptions) done(res result.Result) {
+	if o.stats != nil && res != nil {
+		*o.stats = res.Stats()
+	}
+}
+
+// sessionQuerier executes data queries of the session with the options
+type sessionQuerier struct {
+	yscan.SessionQuerier
+	o executeOptions
+}
+
+func (q sessionQuerier) Execute(
+	ctx context.Context,
+	txc *table.TransactionControl,
+	query string,
+	params *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (table.Transaction, result.Result, error) {
+	tx, res, err := q.SessionQuerier.Execute(q.o.context(ctx), txc, query, params, q.o.options(opts)...)
+	q.o.done(res)
+	return tx, res, err
+}
+
+// transactionQuerier executes data queries of the transaction with the options
+type transactionQuerier struct {
+	yscan.TransactionQuerier
+	o executeOptions
+}
+
+func (q transactionQuerier) Execute(
+	ctx context.Context,
+	query string,
+	params *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (result.Result, error) {
+	res, err := q.TransactionQuerier.Execute(q.o.context(ctx), query, params, q.o.options(opts)...)
+	q.o.done(res)
+	return res, err
+}
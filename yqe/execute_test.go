@@ -0,0 +1,104 @@
+package yqe
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+)
+
+type queryStatsStub struct {
+	stats.QueryStats
+}
+
+type txActorStub struct {
+	table.TransactionActor
+	opts   []options.ExecuteDataQueryOption
+	result *resultStub
+}
+
+func (tx *txActorStub) Execute(
+	_ context.Context,
+	_ string,
+	_ *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (result.Result, error) {
+	tx.opts = opts
+	return tx.result, nil
+}
+
+// collectStatsMode returns the stats collection mode set by the options
+func collectStatsMode(opts []options.ExecuteDataQueryOption) string {
+	desc := &options.ExecuteDataQueryDesc{}
+	// the request of the desc is a generated proto type, it's set without the import
+	request := reflect.ValueOf(desc).Elem().Field(0)
+	request.Set(reflect.New(request.Type().Elem()))
+	for _, opt := range opts {
+		opt.ApplyExecuteDataQueryOption(desc, nil)
+	}
+	return desc.CollectStats.String()
+}
+
+func TestExecutorExecuteOptions(t *testing.T) {
+	ctx := context.Background()
+	res := newResultStub([]string{"id"}, []map[string]any{{"id": int64(1)}})
+	res.stats = &queryStatsStub{}
+	s := &sessionStub{result: res}
+	e := UseSession(s).WithExecuteOptions(OperationTimeout(time.Second))
+
+	var (
+		ids        []int64
+		queryStats stats.QueryStats
+	)
+	_, err := e.SelectX(ctx, &ids, yqb.Select("id").From("users"), CollectStats(&queryStats), CancelAfter(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+	assert.Same(t, res.stats, queryStats)
+	assert.Len(t, s.opts, 1)
+	assert.Equal(t, "STATS_COLLECTION_BASIC", collectStatsMode(s.opts))
+	assert.NotEqual(t, ctx, s.ctx)
+
+	ids, queryStats = nil, nil
+	s.result = newResultStub([]string{"id"}, []map[string]any{{"id": int64(2)}})
+	s.result.stats = &queryStatsStub{}
+	_, err = e.SelectMultiX(ctx, yqb.Multi(yqb.Select("id").From("users")), []any{&ids}, CollectStats(&queryStats))
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, ids)
+	assert.Same(t, s.result.stats, queryStats)
+	assert.Equal(t, "STATS_COLLECTION_BASIC", collectStatsMode(s.opts))
+
+	_, _, err = e.Exec(ctx, "SELECT 1", nil, WithDataQueryOptions(options.WithCollectStatsModeNone()))
+	assert.NoError(t, err)
+	assert.Equal(t, "STATS_COLLECTION_NONE", collectStatsMode(s.opts))
+
+	tx := &txActorStub{result: newResultStub([]string{"id"}, []map[string]any{{"id": int64(1)}})}
+	tx.result.stats = &queryStatsStub{}
+	queryStats = nil
+	var id int64
+	_, err = UseTransaction(tx).GetX(ctx, &id, yqb.Select("id").From("users"), CollectStats(&queryStats))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+	assert.Same(t, tx.result.stats, queryStats)
+	assert.Equal(t, "STATS_COLLECTION_BASIC", collectStatsMode(tx.opts))
+}
+
+func TestClientExecuteOptions(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+	c := New(stub, WithExecuteOptions(CollectStats(new(stats.QueryStats))))
+
+	_, err := c.ExecX(ctx, yqb.Delete("users").Where(yqb.Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, "STATS_COLLECTION_BASIC", collectStatsMode(stub.session.opts))
+
+	_, err = c.ExecX(ctx, yqb.Delete("users").Where(yqb.Eq{"id": 1}), WithExecuteOptions(WithDataQueryOptions(options.WithCollectStatsModeNone())))
+	assert.NoError(t, err)
+	assert.Equal(t, "STATS_COLLECTION_NONE", collectStatsMode(stub.session.opts))
+}
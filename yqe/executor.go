@@ -11,25 +11,27 @@ import (
 
 // SqlizedExecer exec query use qb.YdbSqlizer
 type SqlizedExecer interface {
-	Exec(ctx context.Context, query string, params []table.ParameterOption) (table.Transaction, result.Result, error)
-	ExecX(ctx context.Context, query yqb.YdbSqlizer) (table.Transaction, result.Result, error)
+	Exec(ctx context.Context, query string, params []table.ParameterOption, opts ...ExecuteOption) (table.Transaction, result.Result, error)
+	ExecX(ctx context.Context, query yqb.YdbSqlizer, opts ...ExecuteOption) (table.Transaction, result.Result, error)
 }
 
 // SqlizedGetter get query use qb.YdbSqlizer
 type SqlizedGetter interface {
-	Get(ctx context.Context, dst any, query string, params []table.ParameterOption) (table.Transaction, error)
-	GetX(ctx context.Context, dst any, query yqb.YdbSqlizer) (table.Transaction, error)
+	Get(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...ExecuteOption) (table.Transaction, error)
+	GetX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...ExecuteOption) (table.Transaction, error)
 }
 
 // SqlizedSelector select query use qb.YdbSqlizer
 type SqlizedSelector interface {
-	Select(ctx context.Context, dst any, query string, params []table.ParameterOption) (table.Transaction, error)
-	SelectX(ctx context.Context, dst any, query yqb.YdbSqlizer) (table.Transaction, error)
+	Select(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...ExecuteOption) (table.Transaction, error)
+	SelectX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...ExecuteOption) (table.Transaction, error)
 }
 
 // Executor runner query
 type Executor struct {
 	txc               *table.TransactionControl
+	autoTxc           bool
+	execOpts          []ExecuteOption
 	sessionRunner     yscan.SessionQuerier
 	transactionRunner yscan.TransactionQuerier
 }
@@ -53,6 +55,38 @@ func UseTransaction(runner yscan.TransactionQuerier) *Executor {
 func (e *Executor) WithTxControl(txc *table.TransactionControl) *Executor {
 	if txc != nil {
 		e.txc = txc
+		e.autoTxc = false
 	}
 	return e
 }
+
+// WithAutoTxControl choose table.TransactionControl by yqb.StatementKindOf of the query:
+// OnlineRO for queries of only read statements and SerializableRW for others
+func (e *Executor) WithAutoTxControl() *Executor {
+	e.autoTxc = true
+	return e
+}
+
+// WithExecuteOptions set the default ExecuteOption of the queries, the options of a call are applied after them
+func (e *Executor) WithExecuteOptions(opts ...ExecuteOption) *Executor {
+	e.execOpts = append(e.execOpts, opts...)
+	return e
+}
+
+// session returns the session runner executing queries with the options
+func (e *Executor) session(opts []ExecuteOption) yscan.SessionQuerier {
+	return sessionQuerier{SessionQuerier: e.sessionRunner, o: applyExecuteOptions(e.execOpts, opts)}
+}
+
+// transaction returns the transaction runner executing queries with the options
+func (e *Executor) transaction(opts []ExecuteOption) yscan.TransactionQuerier {
+	return transactionQuerier{TransactionQuerier: e.transactionRunner, o: applyExecuteOptions(e.execOpts, opts)}
+}
+
+// txControl returns table.TransactionControl of the query
+func (e *Executor) txControl(query string) *table.TransactionControl {
+	if e.autoTxc {
+		return txControlOf(yqb.StatementKindOf(query))
+	}
+	return e.txc
+}
@@ -9,21 +9,21 @@ import (
 )
 
 // Get accepting SQL string and parameters
-func (e *Executor) Get(ctx context.Context, dst any, query string, params []table.ParameterOption) (table.Transaction, error) {
+func (e *Executor) Get(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...ExecuteOption) (table.Transaction, error) {
 	var (
 		tx  table.Transaction
 		err error
 	)
 	switch {
 	case e.sessionRunner != nil:
-		tx, err = ScanDefaultAPI.Get(ctx, e.txc, e.sessionRunner, dst, query, table.NewQueryParameters(
+		tx, err = ScanDefaultAPI.Get(ctx, e.txControl(query), e.session(opts), dst, query, table.NewQueryParameters(
 			params...,
 		))
 		if err != nil {
 			return tx, fmt.Errorf("ScanDefaultAPI.Get: %w", err)
 		}
 	case e.transactionRunner != nil:
-		err = ScanDefaultAPI.GetTx(ctx, e.transactionRunner, dst, query, table.NewQueryParameters(
+		err = ScanDefaultAPI.GetTx(ctx, e.transaction(opts), dst, query, table.NewQueryParameters(
 			params...,
 		))
 		tx, _ = e.transactionRunner.(table.Transaction)
@@ -38,11 +38,13 @@ func (e *Executor) Get(ctx context.Context, dst any, query string, params []tabl
 }
 
 // GetX select accepting SQL builder
-func (e *Executor) GetX(ctx context.Context, dst any, query yqb.YdbSqlizer) (table.Transaction, error) {
+//
+// The builder can be a write builder with Returning, e.g. to get generated columns.
+func (e *Executor) GetX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...ExecuteOption) (table.Transaction, error) {
 	ydbSqlStr, ydbParams, err := query.ToYdbSql()
 	if err != nil {
 		return nil, fmt.Errorf("query.ToYdbSql: %w", err)
 	}
 
-	return e.Get(ctx, dst, ydbSqlStr, ydbParams)
+	return e.Get(ctx, dst, ydbSqlStr, ydbParams, opts...)
 }
@@ -0,0 +1,158 @@
+package yqe
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+// DefaultPageSize is the page size of Paginate and SelectPagesX for zero,
+// it's the row limit of YDB result sets
+const DefaultPageSize = 1000
+
+// pagination is the keyset pagination of SelectX, see Paginate
+type pagination struct {
+	pageSize uint64
+	keys     []string
+}
+
+// Paginate reads the rows of yqb.SelectBuilder by pages of pageSize rows ordered by the keys,
+// e.g. the primary key, so SelectX isn't limited by the row limit of YDB result sets
+//
+// Every page is a query of its own with LIMIT and a WHERE predicate continuing the previous page,
+// so queries with ORDER BY, LIMIT or OFFSET are rejected. The keys must be unique together, not NULL,
+// and be selected into fields of dst by the db tag or the snake case field name, the IdentMode
+// of the query is applied to them.
+//
+// Client.SelectX reads all pages in one snapshot read-only transaction in table.Client.DoTx,
+// so the pages are consistent with each other, TxControl and AutoTxControl aren't used.
+func Paginate(pageSize uint64, keys ...string) Option {
+	return func(o *callOptions) {
+		o.pagination = &pagination{pageSize: pageSize, keys: keys}
+	}
+}
+
+// SelectPagesX select accepting SQL builder by pages of pageSize rows ordered by the keys, see Paginate
+//
+// The pages are read with the transaction control of the Executor. With a session every page
+// is a transaction of its own and the pages aren't consistent with each other under concurrent
+// writes, use the Executor of a snapshot read-only transaction for a consistent read.
+func (e *Executor) SelectPagesX(ctx context.Context, dst any, query yqb.SelectBuilder, pageSize uint64, keys ...string) (table.Transaction, error) {
+	var tx table.Transaction
+	err := selectPages(dst, query, pagination{pageSize: pageSize, keys: keys}, func(page any, query yqb.SelectBuilder) (err error) {
+		tx, err = e.SelectX(ctx, page, query)
+		return err
+	})
+	return tx, err
+}
+
+// selectPages runs selectPage for the pages of the query until a page has less than pageSize rows,
+// the rows of the pages are appended to dst
+func selectPages(dst any, query yqb.SelectBuilder, p pagination, selectPage func(page any, query yqb.SelectBuilder) error) error {
+	if len(p.keys) == 0 {
+		return fmt.Errorf("pagination must have at least one key")
+	}
+	if query.HasOrderBy() || query.HasLimit() || query.HasOffset() {
+		return fmt.Errorf("pagination of a query with ORDER BY, LIMIT or OFFSET isn't supported")
+	}
+	if p.pageSize == 0 {
+		p.pageSize = DefaultPageSize
+	}
+
+	rows, err := sliceOf(dst)
+	if err != nil {
+		return err
+	}
+
+	pageQuery := query.OrderBy(p.keys...).Limit(p.pageSize)
+	for {
+		page := reflect.New(rows.Type())
+		if err := selectPage(page.Interface(), pageQuery); err != nil {
+			return err
+		}
+		page = page.Elem()
+		rows.Set(reflect.AppendSlice(rows, page))
+
+		if uint64(page.Len()) < p.pageSize {
+			return nil
+		}
+
+		after, err := keysetAfter(page.Index(page.Len()-1), p.keys)
+		if err != nil {
+			return err
+		}
+		pageQuery = query.Where(after).OrderBy(p.keys...).Limit(p.pageSize)
+	}
+}
+
+// sliceOf returns the slice of the pointer dst
+func sliceOf(dst any) (reflect.Value, error) {
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("destination must be a pointer to a slice, got: %T", dst)
+	}
+	return dstVal.Elem(), nil
+}
+
+// keysetAfter returns the predicate of the rows after the row in the order of the keys,
+// e.g. a > $a OR (a = $a AND b > $b)
+func keysetAfter(row reflect.Value, keys []string) (yqb.Sqlizer, error) {
+	for row.Kind() == reflect.Ptr {
+		row = row.Elem()
+	}
+	if row.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pagination row must be a struct, got: %s", row.Type())
+	}
+
+	vals := make([]any, len(keys))
+	for i, key := range keys {
+		field, ok := fieldByColumn(row, key)
+		if !ok {
+			return nil, fmt.Errorf("pagination key %q isn't a field of %s", key, row.Type())
+		}
+		// key > NULL matches no rows, so the pages would end early
+		if (field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface) && field.IsNil() {
+			return nil, fmt.Errorf("pagination key %q is NULL", key)
+		}
+		vals[i] = field.Interface()
+	}
+
+	after := make(yqb.Or, 0, len(keys))
+	for i, key := range keys {
+		pred := yqb.And{}
+		for j := 0; j < i; j++ {
+			pred = append(pred, yqb.Eq{keys[j]: vals[j]})
+		}
+		pred = append(pred, yqb.Gt{key: vals[i]})
+		after = append(after, pred)
+	}
+	return after, nil
+}
+
+// fieldByColumn returns the field of the struct scanned from the column,
+// the table alias of the column is ignored, the field of a nil embedded
+// struct pointer is returned as a nil pointer
+func fieldByColumn(v reflect.Value, column string) (reflect.Value, bool) {
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		column = column[i+1:]
+	}
+
+	columns, err := structColumns(v.Type())
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	for _, c := range columns {
+		if c.name != column {
+			continue
+		}
+		if field, ok := c.field(v); ok {
+			return field, true
+		}
+		return reflect.Zero(reflect.PtrTo(v.Type().FieldByIndex(c.index).Type)), true
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,121 @@
+package yqe
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/flymedllva/ydb-go-qb/yscan"
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+type PageRow struct {
+	ID   int64 `db:"id"`
+	Name string
+}
+
+func TestClientSelectXPaginate(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "name"}
+	stub := &clientStub{results: []*resultStub{
+		newResultStub(columns, []map[string]any{{"id": int64(1), "name": "a"}, {"id": int64(2), "name": "b"}}),
+		newResultStub(columns, []map[string]any{{"id": int64(3), "name": "c"}}),
+	}}
+	c := New(stub)
+
+	var rows []PageRow
+	err := c.SelectX(ctx, &rows, yqb.Select("id", "name").From("users").Where(yqb.Eq{"active": true}), Paginate(2, "id"))
+	assert.NoError(t, err)
+	assert.Equal(t, []PageRow{{1, "a"}, {2, "b"}, {3, "c"}}, rows)
+	assert.Equal(t, []string{
+		"DECLARE $p1 AS Bool;\nSELECT id, name FROM users WHERE active = $p1 ORDER BY id LIMIT 2",
+		"DECLARE $p1 AS Bool;\nDECLARE $p2 AS Int64;\nSELECT id, name FROM users WHERE active = $p1 AND ((id > $p2)) ORDER BY id LIMIT 2",
+	}, stub.queries)
+	assert.True(t, stub.options.Idempotent)
+	assert.Equal(t, table.TxSettings(table.WithSnapshotReadOnly()).Settings(), stub.options.TxSettings.Settings())
+
+	err = c.SelectX(ctx, &rows, yqb.Delete("users"), Paginate(2, "id"))
+	assert.Error(t, err)
+
+	for _, query := range []yqb.SelectBuilder{
+		yqb.Select("id", "name").From("users").OrderBy("name"),
+		yqb.Select("id", "name").From("users").Limit(10),
+		yqb.Select("id", "name").From("users").Offset(10),
+	} {
+		err = c.SelectX(ctx, &rows, query, Paginate(2, "id"))
+		assert.ErrorContains(t, err, "ORDER BY, LIMIT or OFFSET")
+	}
+}
+
+func TestClientSelectXPaginateIdentMode(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "name"}
+	stub := &clientStub{results: []*resultStub{
+		newResultStub(columns, []map[string]any{{"id": int64(1), "name": "a"}}),
+		newResultStub(columns, nil),
+	}}
+	c := New(stub)
+
+	var rows []PageRow
+	query := yqb.StatementBuilder.IdentMode(yqb.IdentQuote).Select("id", "name").From("users")
+	err := c.SelectX(ctx, &rows, query, Paginate(1, "id"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"SELECT id, name FROM `users` ORDER BY `id` LIMIT 1",
+		"DECLARE $p1 AS Int64;\nSELECT id, name FROM `users` WHERE ((`id` > $p1)) ORDER BY `id` LIMIT 1",
+	}, stub.queries)
+
+	query = yqb.StatementBuilder.IdentMode(yqb.IdentValidate).Select("id", "name").From("users")
+	err = c.SelectX(ctx, &rows, query, Paginate(1, "id; DROP TABLE users"))
+	assert.ErrorIs(t, err, yqb.ErrInvalidIdent)
+}
+
+func TestKeysetAfter(t *testing.T) {
+	row := struct {
+		PageRow
+		CreatedAt string `db:"created_at"`
+	}{PageRow: PageRow{ID: 2, Name: "b"}, CreatedAt: "2023-01-01"}
+
+	after, err := keysetAfter(reflect.ValueOf(&row), []string{"t.created_at", "id"})
+	assert.NoError(t, err)
+
+	sql, args, err := after.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "((t.created_at > ?) OR (t.created_at = ? AND id > ?))", sql)
+	assert.Equal(t, []any{"2023-01-01", "2023-01-01", int64(2)}, args)
+
+	_, err = keysetAfter(reflect.ValueOf(row), []string{"email"})
+	assert.Error(t, err)
+
+	nullable := struct {
+		ID    int64   `db:"id"`
+		Email *string `db:"email"`
+	}{ID: 1}
+	_, err = keysetAfter(reflect.ValueOf(nullable), []string{"email", "id"})
+	assert.ErrorContains(t, err, `pagination key "email" is NULL`)
+
+	embedded := struct {
+		*PageRow
+	}{}
+	_, err = keysetAfter(reflect.ValueOf(embedded), []string{"id"})
+	assert.ErrorContains(t, err, `pagination key "id" is NULL`)
+
+	embedded.PageRow = &PageRow{ID: 3}
+	after, err = keysetAfter(reflect.ValueOf(embedded), []string{"id"})
+	assert.NoError(t, err)
+	_, args, err = after.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, []any{int64(3)}, args)
+}
+
+func TestExecutorSelectTruncated(t *testing.T) {
+	ctx := context.Background()
+	res := newResultStub([]string{"id", "name"}, []map[string]any{{"id": int64(1), "name": "a"}})
+	res.truncated = true
+
+	var rows []PageRow
+	_, err := UseSession(&sessionStub{result: res}).SelectX(ctx, &rows, yqb.Select("id", "name").From("users"))
+	assert.ErrorIs(t, err, yscan.ErrTruncated)
+}
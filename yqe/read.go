@@ -0,0 +1,187 @@
+package yqe
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// WithReadRowsOptions sets the options of table.Session.ReadRows, e.g. options.ReadColumns
+func WithReadRowsOptions(opts ...options.ReadRowsOption) TableOption {
+	return func(o *tableOptions) {
+		o.readRowsOpts = append(o.readRowsOpts, opts...)
+	}
+}
+
+// WithReadTableOptions sets the options of table.Session.StreamReadTable, e.g. options.ReadRowLimit
+func WithReadTableOptions(opts ...options.ReadTableOption) TableOption {
+	return func(o *tableOptions) {
+		o.readTableOpts = append(o.readTableOpts, opts...)
+	}
+}
+
+// ReadRows reads the rows of the primary keys with table.Session.ReadRows and scans them into []T,
+// the path of the table must be full, e.g. path.Join(db.Name(), "users")
+//
+// The keys are structs of the primary key columns by the db tag, the column types are taken
+// like in BulkUpsert. The rows of missing keys are skipped, the order of the rows is undefined.
+// Ex:
+//
+//	type UserKey struct {
+//		ID uint64 `db:"id"`
+//	}
+//
+//	users, err := yqe.ReadRows[User](ctx, db.Table(), path.Join(db.Name(), "users"), []UserKey{{1}, {2}})
+func ReadRows[T, K any](ctx context.Context, client table.Client, path string, keys []K, opts ...TableOption) ([]T, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	o := applyTableOptions(opts)
+	if err := o.describeTable(ctx, client, path); err != nil {
+		return nil, err
+	}
+	tableTypes := columnTypes(o.desc)
+
+	columns, err := structColumnsOf[K]("key")
+	if err != nil {
+		return nil, err
+	}
+	if err = checkColumns(columns, tableTypes); err != nil {
+		return nil, err
+	}
+	keyValues := make([]types.Value, 0, len(keys))
+	for i := range keys {
+		key, err := structValue(reflect.ValueOf(&keys[i]).Elem(), columns, tableTypes)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		keyValues = append(keyValues, key)
+	}
+
+	var rows []T
+	err = client.Do(ctx, func(ctx context.Context, s table.Session) error {
+		rows = nil
+		r, err := s.ReadRows(ctx, path, types.ListValue(keyValues...), o.readRowsOpts...)
+		if err != nil {
+			return fmt.Errorf("session.ReadRows: %w", err)
+		}
+		defer r.Close()
+
+		if err = r.NextResultSetErr(ctx); err != nil {
+			return fmt.Errorf("result.NextResultSetErr: %w", err)
+		}
+		if err = ScanDefaultAPI.ScanAll(&rows, r); err != nil {
+			return fmt.Errorf("ScanDefaultAPI.ScanAll: %w", err)
+		}
+		return nil
+	}, table.WithIdempotent())
+	if err != nil {
+		return nil, fmt.Errorf("client.Do: %w", err)
+	}
+	return rows, nil
+}
+
+// ReadRange reads the rows of the primary key range [from, to) with table.Session.StreamReadTable
+// in the order of the primary key and scans them into []T, nil from or to is unbounded
+//
+// The bounds are structs of a prefix of the primary key columns by the db tag, in the order of
+// the primary key if the table description is known, see DescribeTable, and in the order of
+// the fields otherwise. Only the columns cols are read, all columns for empty cols.
+// Ex:
+//
+//	type EventKey struct {
+//		UserID uint64 `db:"user_id"`
+//	}
+//
+//	events, err := yqe.ReadRange[Event](ctx, db.Table(), path.Join(db.Name(), "events"),
+//		&EventKey{UserID: 1}, &EventKey{UserID: 2}, []string{"user_id", "id", "name"}, yqe.DescribeTable())
+func ReadRange[T, K any](ctx context.Context, client table.Client, path string, from, to *K, cols []string, opts ...TableOption) ([]T, error) {
+	o := applyTableOptions(opts)
+	if err := o.describeTable(ctx, client, path); err != nil {
+		return nil, err
+	}
+
+	readOpts := []options.ReadTableOption{options.ReadOrdered()}
+	if len(cols) > 0 {
+		readOpts = append(readOpts, options.ReadColumns(cols...))
+	}
+	if from != nil || to != nil {
+		columns, err := keyColumns[K](o.desc)
+		if err != nil {
+			return nil, err
+		}
+		tableTypes := columnTypes(o.desc)
+		if from != nil {
+			key, err := tupleValue(reflect.ValueOf(from).Elem(), columns, tableTypes)
+			if err != nil {
+				return nil, fmt.Errorf("from: %w", err)
+			}
+			readOpts = append(readOpts, options.ReadGreaterOrEqual(key))
+		}
+		if to != nil {
+			key, err := tupleValue(reflect.ValueOf(to).Elem(), columns, tableTypes)
+			if err != nil {
+				return nil, fmt.Errorf("to: %w", err)
+			}
+			readOpts = append(readOpts, options.ReadLess(key))
+		}
+	}
+	readOpts = append(readOpts, o.readTableOpts...)
+
+	var rows []T
+	err := client.Do(ctx, func(ctx context.Context, s table.Session) error {
+		rows = nil
+		return StreamTableFunc(ctx, UseSession(s), path, func(row T) error {
+			rows = append(rows, row)
+			return nil
+		}, readOpts...)
+	}, table.WithIdempotent())
+	if err != nil {
+		return nil, fmt.Errorf("client.Do: %w", err)
+	}
+	return rows, nil
+}
+
+// keyColumns returns the columns of the key K in the order of the primary key of the table,
+// they must be its prefix. The columns are in the order of the fields without the table description.
+func keyColumns[K any](desc *options.Description) ([]structColumn, error) {
+	columns, err := structColumnsOf[K]("key")
+	if err != nil || desc == nil {
+		return columns, err
+	}
+
+	byName := make(map[string]structColumn, len(columns))
+	for _, c := range columns {
+		byName[c.name] = c
+	}
+	ordered := make([]structColumn, 0, len(columns))
+	for _, name := range desc.PrimaryKey {
+		c, ok := byName[name]
+		if !ok {
+			break
+		}
+		ordered = append(ordered, c)
+	}
+	if len(ordered) != len(columns) {
+		return nil, fmt.Errorf("key columns must be a prefix of the primary key %v", desc.PrimaryKey)
+	}
+	return ordered, nil
+}
+
+// tupleValue returns the YDB tuple value of the columns of the struct v
+func tupleValue(v reflect.Value, columns []structColumn, tableTypes map[string]types.Type) (types.Value, error) {
+	values := make([]types.Value, 0, len(columns))
+	for _, c := range columns {
+		val, err := c.value(v, tableTypes)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+	}
+	return types.TupleValue(values...), nil
+}
@@ -0,0 +1,100 @@
+package yqe
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func (s tableSessionStub) ReadRows(_ context.Context, path string, keys types.Value, _ ...options.ReadRowsOption) (result.Result, error) {
+	s.c.path, s.c.keys = path, keys.Yql()
+	return s.c.result, nil
+}
+
+func (s tableSessionStub) StreamReadTable(_ context.Context, path string, opts ...options.ReadTableOption) (result.StreamResult, error) {
+	s.c.path, s.c.readOpts = path, opts
+	return s.c.result, nil
+}
+
+type eventKey struct {
+	ID     uint64 `db:"id"`
+	UserID uint64 `db:"user_id"`
+}
+
+type event struct {
+	UserID uint64 `db:"user_id"`
+	ID     uint64 `db:"id"`
+	Name   string `db:"name"`
+}
+
+func TestReadRows(t *testing.T) {
+	ctx := context.Background()
+	stub := &tableClientStub{
+		result: newResultStub([]string{"user_id", "id", "name"}, []map[string]any{
+			{"user_id": uint64(1), "id": uint64(1), "name": "a"},
+		}),
+		desc: options.Description{Columns: []options.Column{
+			{Name: "user_id", Type: types.Optional(types.TypeUint64)},
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+			{Name: "name", Type: types.Optional(types.TypeText)},
+		}},
+	}
+
+	rows, err := ReadRows[event](ctx, stub, "/local/events", []eventKey{{ID: 1, UserID: 1}, {ID: 2, UserID: 1}}, DescribeTable())
+	assert.NoError(t, err)
+	assert.Equal(t, []event{{UserID: 1, ID: 1, Name: "a"}}, rows)
+	assert.Equal(t, "/local/events", stub.path)
+	assert.Equal(t, "[<|`id`:Just(1ul),`user_id`:Just(1ul)|>,<|`id`:Just(2ul),`user_id`:Just(1ul)|>]", stub.keys)
+
+	_, err = ReadRows[event](ctx, stub, "/local/events", []struct {
+		Email string `db:"email"`
+	}{{"a"}}, DescribeTable())
+	assert.EqualError(t, err, `column "email" isn't in the table`)
+}
+
+func TestReadRange(t *testing.T) {
+	ctx := context.Background()
+	stub := &tableClientStub{
+		result: newResultStub([]string{"user_id", "id", "name"},
+			[]map[string]any{{"user_id": uint64(1), "id": uint64(1), "name": "a"}},
+			[]map[string]any{{"user_id": uint64(1), "id": uint64(2), "name": "b"}},
+		),
+	}
+
+	rows, err := ReadRange[event](ctx, stub, "/local/events", &eventKey{UserID: 1}, nil, []string{"user_id", "id", "name"})
+	assert.NoError(t, err)
+	assert.Equal(t, []event{{UserID: 1, ID: 1, Name: "a"}, {UserID: 1, ID: 2, Name: "b"}}, rows)
+	assert.Len(t, stub.readOpts, 3)
+}
+
+func TestKeyColumns(t *testing.T) {
+	desc := &options.Description{
+		PrimaryKey: []string{"user_id", "id"},
+		Columns: []options.Column{
+			{Name: "user_id", Type: types.Optional(types.TypeUint64)},
+			{Name: "id", Type: types.Optional(types.TypeUint64)},
+		},
+	}
+
+	columns, err := keyColumns[eventKey](desc)
+	assert.NoError(t, err)
+	key, err := tupleValue(reflect.ValueOf(eventKey{ID: 2, UserID: 1}), columns, columnTypes(desc))
+	assert.NoError(t, err)
+	assert.Equal(t, "(Just(1ul),Just(2ul))", key.Yql())
+
+	columns, err = keyColumns[eventKey](nil)
+	assert.NoError(t, err)
+	key, err = tupleValue(reflect.ValueOf(eventKey{ID: 2, UserID: 1}), columns, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "(2ul,1ul)", key.Yql())
+
+	_, err = keyColumns[struct {
+		ID uint64 `db:"id"`
+	}](desc)
+	assert.EqualError(t, err, "key columns must be a prefix of the primary key [user_id id]")
+}
@@ -0,0 +1,112 @@
+package yqe
+
+import (
+	"context"
+	"io"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+)
+
+// resultStub is a result of result sets of rows, the rows are maps of columns to values
+type resultStub struct {
+	result.Result
+	columns   []string
+	sets      [][]map[string]any
+	set       int
+	row       int
+	closed    bool
+	truncated bool
+	stats     stats.QueryStats
+}
+
+func newResultStub(columns []string, sets ...[]map[string]any) *resultStub {
+	return &resultStub{columns: columns, sets: sets, set: -1}
+}
+
+type setStub struct {
+	result.Set
+	r *resultStub
+}
+
+func (s setStub) ColumnCount() int {
+	return len(s.r.columns)
+}
+
+func (s setStub) Columns(it func(options.Column)) {
+	for _, c := range s.r.columns {
+		it(options.Column{Name: c})
+	}
+}
+
+func (s setStub) RowCount() int {
+	return len(s.r.sets[s.r.set])
+}
+
+func (s setStub) Truncated() bool {
+	return s.r.truncated
+}
+
+func (r *resultStub) ResultSetCount() int {
+	return len(r.sets)
+}
+
+func (r *resultStub) HasNextResultSet() bool {
+	return r.set+1 < len(r.sets)
+}
+
+func (r *resultStub) NextResultSetErr(context.Context, ...string) error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.row = -1
+	return nil
+}
+
+func (r *resultStub) NextResultSet(ctx context.Context, columns ...string) bool {
+	return r.NextResultSetErr(ctx, columns...) == nil
+}
+
+func (r *resultStub) CurrentResultSet() result.Set {
+	return setStub{r: r}
+}
+
+func (r *resultStub) NextRow() bool {
+	if r.row+1 >= len(r.sets[r.set]) {
+		return false
+	}
+	r.row++
+	return true
+}
+
+func (r *resultStub) ScanNamed(values ...named.Value) error {
+	row := r.sets[r.set][r.row]
+	for _, v := range values {
+		dst := reflect.ValueOf(v.Value).Elem()
+		val := reflect.ValueOf(row[v.Name])
+		if dst.Kind() == reflect.Ptr && val.Kind() != reflect.Ptr {
+			p := reflect.New(dst.Type().Elem())
+			p.Elem().Set(val)
+			val = p
+		}
+		dst.Set(val)
+	}
+	return nil
+}
+
+func (r *resultStub) Stats() stats.QueryStats {
+	return r.stats
+}
+
+func (r *resultStub) Err() error {
+	return nil
+}
+
+func (r *resultStub) Close() error {
+	r.closed = true
+	return nil
+}
@@ -0,0 +1,64 @@
+package yqe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/flymedllva/ydb-go-qb/yscan"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+)
+
+// ErrNotSchemeQuery is returned by ExecSchemeX for builders of other statements than DDL
+var ErrNotSchemeQuery = errors.New("query isn't a scheme query")
+
+// ExecScheme runs the scheme query, e.g. CREATE TABLE, with ExecuteSchemeQuery
+//
+// The Executor must be created by UseSession with table.Session, scheme queries
+// can't run in transactions and can't have parameters.
+func (e *Executor) ExecScheme(ctx context.Context, query string, opts ...options.ExecuteSchemeQueryOption) error {
+	querier, ok := e.sessionRunner.(yscan.SchemeQuerier)
+	if !ok {
+		return fmt.Errorf("runner doesn't support scheme queries")
+	}
+
+	if err := querier.ExecuteSchemeQuery(ctx, query, opts...); err != nil {
+		return fmt.Errorf("querier.ExecuteSchemeQuery: %w", err)
+	}
+	return nil
+}
+
+// ExecSchemeX exec scheme accepting SQL builder, e.g. yqb.Create or yqb.Drop,
+// the values of the parameters are inlined by yqb.InlineYql
+//
+// It returns ErrNotSchemeQuery for builders of other statements, see yqb.Describe.
+func (e *Executor) ExecSchemeX(ctx context.Context, query yqb.YdbSqlizer, opts ...options.ExecuteSchemeQueryOption) error {
+	yql, err := schemeYql(query)
+	if err != nil {
+		return err
+	}
+
+	return e.ExecScheme(ctx, yql, opts...)
+}
+
+// schemeYql returns the scheme query of the builder with the inlined parameters
+func schemeYql(query yqb.YdbSqlizer) (string, error) {
+	info, err := yqb.Describe(query)
+	if err != nil {
+		return "", fmt.Errorf("yqb.Describe: %w", err)
+	}
+	if info.Kind != yqb.StatementDDL {
+		return "", fmt.Errorf("%w: %s statement", ErrNotSchemeQuery, info.Kind)
+	}
+
+	sqlizer, ok := query.(yqb.Sqlizer)
+	if !ok {
+		return "", fmt.Errorf("%w: %T has no ToSql", ErrNotSchemeQuery, query)
+	}
+	yql, err := yqb.InlineYql(sqlizer)
+	if err != nil {
+		return "", fmt.Errorf("yqb.InlineYql: %w", err)
+	}
+	return yql, nil
+}
@@ -0,0 +1,42 @@
+package yqe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutorExecSchemeX(t *testing.T) {
+	ctx := context.Background()
+	s := &sessionStub{}
+
+	err := UseSession(s).ExecSchemeX(ctx, yqb.Create("events").
+		Columns("id", "ts").
+		Types("Uint64", "Timestamp").
+		PrimaryKey("id").
+		Suffix("WITH (TTL = Interval(?) ON ts)", "P7D"))
+	assert.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE events ( id Uint64, ts Timestamp, PRIMARY KEY (id) ) WITH (TTL = Interval("P7D") ON ts)`, s.query)
+
+	err = UseSession(s).ExecSchemeX(ctx, yqb.Drop("events"))
+	assert.NoError(t, err)
+	assert.Equal(t, "DROP TABLE events", s.query)
+
+	err = UseSession(s).ExecSchemeX(ctx, yqb.Select("id").From("events"))
+	assert.ErrorIs(t, err, ErrNotSchemeQuery)
+
+	err = UseTransaction(nil).ExecScheme(ctx, "DROP TABLE events")
+	assert.Error(t, err)
+}
+
+func TestClientExecSchemeX(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+
+	err := New(stub).ExecSchemeX(ctx, yqb.Drop("events"), Idempotent())
+	assert.NoError(t, err)
+	assert.True(t, stub.options.Idempotent)
+	assert.Equal(t, "DROP TABLE events", stub.session.query)
+}
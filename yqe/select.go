@@ -8,21 +8,21 @@ import (
 )
 
 // Select accepting SQL string and parameters
-func (e *Executor) Select(ctx context.Context, dst any, query string, params []table.ParameterOption) (table.Transaction, error) {
+func (e *Executor) Select(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...ExecuteOption) (table.Transaction, error) {
 	var (
 		tx  table.Transaction
 		err error
 	)
 	switch {
 	case e.sessionRunner != nil:
-		tx, err = ScanDefaultAPI.Select(ctx, e.txc, e.sessionRunner, dst, query, table.NewQueryParameters(
+		tx, err = ScanDefaultAPI.Select(ctx, e.txControl(query), e.session(opts), dst, query, table.NewQueryParameters(
 			params...,
 		))
 		if err != nil {
 			return tx, fmt.Errorf("ScanDefaultAPI.Select: %w", err)
 		}
 	case e.transactionRunner != nil:
-		err = ScanDefaultAPI.SelectTx(ctx, e.transactionRunner, dst, query, table.NewQueryParameters(
+		err = ScanDefaultAPI.SelectTx(ctx, e.transaction(opts), dst, query, table.NewQueryParameters(
 			params...,
 		))
 		tx, _ = e.transactionRunner.(table.Transaction)
@@ -37,11 +37,54 @@ func (e *Executor) Select(ctx context.Context, dst any, query string, params []t
 }
 
 // SelectX select accepting SQL builder
-func (e *Executor) SelectX(ctx context.Context, dst any, query yqb.YdbSqlizer) (table.Transaction, error) {
+//
+// The builder can be a write builder with Returning, e.g. to get the changed rows.
+func (e *Executor) SelectX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...ExecuteOption) (table.Transaction, error) {
 	ydbSqlStr, ydbParams, err := query.ToYdbSql()
 	if err != nil {
 		return nil, fmt.Errorf("query.ToYdbSql: %w", err)
 	}
 
-	return e.Select(ctx, dst, ydbSqlStr, ydbParams)
+	return e.Select(ctx, dst, ydbSqlStr, ydbParams, opts...)
+}
+
+// SelectMulti accepting SQL string and parameters, the result sets are scanned into dsts in order
+//
+// A pointer to a slice is scanned like Select, any other destination like Get.
+func (e *Executor) SelectMulti(ctx context.Context, query string, params []table.ParameterOption, dsts []any, opts ...ExecuteOption) (table.Transaction, error) {
+	var (
+		tx  table.Transaction
+		err error
+	)
+	switch {
+	case e.sessionRunner != nil:
+		tx, err = ScanDefaultAPI.SelectMulti(ctx, e.txControl(query), e.session(opts), query, table.NewQueryParameters(
+			params...,
+		), dsts...)
+		if err != nil {
+			return tx, fmt.Errorf("ScanDefaultAPI.SelectMulti: %w", err)
+		}
+	case e.transactionRunner != nil:
+		err = ScanDefaultAPI.SelectMultiTx(ctx, e.transaction(opts), query, table.NewQueryParameters(
+			params...,
+		), dsts...)
+		tx, _ = e.transactionRunner.(table.Transaction)
+		if err != nil {
+			return tx, fmt.Errorf("ScanDefaultAPI.SelectMultiTx: %w", err)
+		}
+	default:
+		return tx, fmt.Errorf("no runner")
+	}
+
+	return tx, nil
+}
+
+// SelectMultiX select multi accepting SQL builder, e.g. yqb.Multi
+func (e *Executor) SelectMultiX(ctx context.Context, query yqb.YdbSqlizer, dsts []any, opts ...ExecuteOption) (table.Transaction, error) {
+	ydbSqlStr, ydbParams, err := query.ToYdbSql()
+	if err != nil {
+		return nil, fmt.Errorf("query.ToYdbSql: %w", err)
+	}
+
+	return e.SelectMulti(ctx, ydbSqlStr, ydbParams, dsts, opts...)
 }
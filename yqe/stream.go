@@ -0,0 +1,72 @@
+package yqe
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/flymedllva/ydb-go-qb/yscan"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// StreamFunc runs the query as a scan query with StreamExecuteScanQuery and calls f
+// with each row scanned into T, the rows aren't held in memory and aren't limited to 1000
+//
+// The Executor must be created by UseSession with table.Session.
+// It stops and returns the error of f if f returns an error.
+// Ex:
+//
+//	err := db.Table().Do(ctx, func(ctx context.Context, s table.Session) error {
+//		return yqe.StreamFunc(ctx, yqe.UseSession(s), yqb.Select("*").From("events"), func(e Event) error {
+//			return enc.Encode(e)
+//		})
+//	})
+func StreamFunc[T any](ctx context.Context, e *Executor, query yqb.YdbSqlizer, f func(row T) error, opts ...options.ExecuteScanQueryOption) error {
+	querier, ok := e.sessionRunner.(yscan.StreamQuerier)
+	if !ok {
+		return fmt.Errorf("runner doesn't support scan queries")
+	}
+
+	ydbSqlStr, ydbParams, err := query.ToYdbSql()
+	if err != nil {
+		return fmt.Errorf("query.ToYdbSql: %w", err)
+	}
+
+	r, err := querier.StreamExecuteScanQuery(ctx, ydbSqlStr, table.NewQueryParameters(ydbParams...), opts...)
+	if err != nil {
+		return fmt.Errorf("querier.StreamExecuteScanQuery: %w", err)
+	}
+
+	return scanEach(ctx, r, f)
+}
+
+// StreamTableFunc reads the table with StreamReadTable and calls f with each row scanned into T
+//
+// The path is the full path of the table, e.g. path.Join(db.Name(), "events").
+// See StreamFunc for details.
+func StreamTableFunc[T any](ctx context.Context, e *Executor, path string, f func(row T) error, opts ...options.ReadTableOption) error {
+	reader, ok := e.sessionRunner.(yscan.TableReader)
+	if !ok {
+		return fmt.Errorf("runner doesn't support read table")
+	}
+
+	r, err := reader.StreamReadTable(ctx, path, opts...)
+	if err != nil {
+		return fmt.Errorf("reader.StreamReadTable: %w", err)
+	}
+
+	return scanEach(ctx, r, f)
+}
+
+func scanEach[T any](ctx context.Context, r result.StreamResult, f func(row T) error) error {
+	defer r.Close()
+
+	var row, zero T
+	return ScanDefaultAPI.ScanEach(ctx, r, &row, func() error {
+		err := f(row)
+		row = zero
+		return err
+	})
+}
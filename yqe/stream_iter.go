@@ -0,0 +1,54 @@
+//go:build go1.23
+
+package yqe
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+)
+
+var errStreamStopped = errors.New("stream stopped")
+
+// Stream is StreamFunc as an iterator, the iteration stops after an error
+// Ex:
+//
+//	for e, err := range yqe.Stream[Event](ctx, yqe.UseSession(s), yqb.Select("*").From("events")) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Stream[T any](ctx context.Context, e *Executor, query yqb.YdbSqlizer, opts ...options.ExecuteScanQueryOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := StreamFunc(ctx, e, query, func(row T) error {
+			if !yield(row, nil) {
+				return errStreamStopped
+			}
+			return nil
+		}, opts...)
+		if err != nil && !errors.Is(err, errStreamStopped) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// StreamTable is StreamTableFunc as an iterator, the iteration stops after an error
+func StreamTable[T any](ctx context.Context, e *Executor, path string, opts ...options.ReadTableOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := StreamTableFunc(ctx, e, path, func(row T) error {
+			if !yield(row, nil) {
+				return errStreamStopped
+			}
+			return nil
+		}, opts...)
+		if err != nil && !errors.Is(err, errStreamStopped) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
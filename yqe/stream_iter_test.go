@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package yqe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	s := &sessionStub{result: newStreamResult()}
+
+	var ids []int64
+	for row, err := range Stream[streamRow](ctx, UseSession(s), yqb.Select("id", "name").From("t")) {
+		assert.NoError(t, err)
+		ids = append(ids, row.ID)
+		if len(ids) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int64{1, 2}, ids)
+	assert.True(t, s.result.closed)
+
+	for _, err := range Stream[streamRow](ctx, UseTransaction(nil), yqb.Select("id").From("t")) {
+		assert.Error(t, err)
+	}
+}
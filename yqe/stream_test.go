@@ -0,0 +1,62 @@
+package yqe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamRow struct {
+	ID   int64   `db:"id"`
+	Name *string `db:"name"`
+}
+
+func newStreamResult() *resultStub {
+	return newResultStub([]string{"id", "name"},
+		[]map[string]any{{"id": int64(1), "name": "a"}, {"id": int64(2), "name": (*string)(nil)}},
+		[]map[string]any{{"id": int64(3), "name": "c"}},
+	)
+}
+
+func TestStreamFunc(t *testing.T) {
+	ctx := context.Background()
+	s := &sessionStub{result: newStreamResult()}
+
+	var rows []streamRow
+	err := StreamFunc(ctx, UseSession(s), yqb.Select("id", "name").From("t").Where(yqb.Gt{"id": 0}), func(row streamRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "DECLARE $p1 AS Int64;\nSELECT id, name FROM t WHERE id > $p1", s.query)
+	assert.True(t, s.result.closed)
+
+	if assert.Len(t, rows, 3) {
+		assert.Equal(t, int64(1), rows[0].ID)
+		assert.Equal(t, "a", *rows[0].Name)
+		assert.Nil(t, rows[1].Name)
+		assert.Equal(t, "c", *rows[2].Name)
+	}
+}
+
+func TestStreamFuncStop(t *testing.T) {
+	ctx := context.Background()
+	s := &sessionStub{result: newStreamResult()}
+	errStop := errors.New("stop")
+
+	n := 0
+	err := StreamFunc(ctx, UseSession(s), yqb.Select("id", "name").From("t"), func(row streamRow) error {
+		n++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, n)
+
+	err = StreamFunc(ctx, UseTransaction(nil), yqb.Select("id").From("t"), func(row streamRow) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package yqe
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// TableOption is an option of the table calls, e.g. BulkUpsert and ReadRows
+type TableOption func(o *tableOptions)
+
+type tableOptions struct {
+	batchBytes    int
+	concurrency   int
+	desc          *options.Description
+	describe      bool
+	upsertOpts    []options.BulkUpsertOption
+	readRowsOpts  []options.ReadRowsOption
+	readTableOpts []options.ReadTableOption
+}
+
+// applyTableOptions applies the options in order
+func applyTableOptions(opts []TableOption) tableOptions {
+	o := tableOptions{
+		batchBytes:  DefaultBulkBatchBytes,
+		concurrency: DefaultBulkConcurrency,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTableDescription sets the description of the table, the column types of it are used
+// for the fields without the ydb tag
+func WithTableDescription(desc options.Description) TableOption {
+	return func(o *tableOptions) {
+		o.desc = &desc
+	}
+}
+
+// DescribeTable describes the table before the call, the column types of it are used
+// for the fields without the ydb tag
+func DescribeTable() TableOption {
+	return func(o *tableOptions) {
+		o.describe = true
+	}
+}
+
+// describeTable sets the description of the table for DescribeTable
+func (o *tableOptions) describeTable(ctx context.Context, client table.Client, path string) error {
+	if !o.describe || o.desc != nil {
+		return nil
+	}
+	err := client.Do(ctx, func(ctx context.Context, s table.Session) error {
+		desc, err := s.DescribeTable(ctx, path)
+		o.desc = &desc
+		return err
+	}, table.WithIdempotent())
+	if err != nil {
+		return fmt.Errorf("client.Do: %w", err)
+	}
+	return nil
+}
+
+// structColumnsOf returns the columns of the struct T, what is the name of T in errors
+func structColumnsOf[T any](what string) ([]structColumn, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s must be a struct, got: %s", what, t)
+	}
+	columns, err := structColumns(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%s must have at least one column", what)
+	}
+	return columns, nil
+}
+
+// checkColumns checks the columns are in the table, if the column types are known
+func checkColumns(columns []structColumn, tableTypes map[string]types.Type) error {
+	if tableTypes == nil {
+		return nil
+	}
+	for _, c := range columns {
+		if _, ok := tableTypes[c.name]; !ok {
+			return fmt.Errorf("column %q isn't in the table", c.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package yqe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// ErrTxDone is returned by Tx statements after the transaction is committed with CommitTx
+var ErrTxDone = errors.New("transaction is already committed")
+
+// ErrUnsupportedOption is returned by InTx and Tx statements for options they don't apply,
+// e.g. TxControl, the transaction control is set by the transaction
+var ErrUnsupportedOption = errors.New("option isn't supported")
+
+// Tx runs statements of one transaction, see InTx
+type Tx struct {
+	session   table.Session
+	settings  table.TxOption
+	execOpts  []ExecuteOption
+	tx        table.Transaction
+	committed bool
+}
+
+// InTx runs f in a session of table.Client.Do with a transaction of the settings,
+// e.g. table.WithSerializableReadWrite(), which is the default for nil.
+//
+// The transaction begins with the first statement of f. It's committed if f returns nil,
+// pass CommitTx to the last statement to commit it with the statement.
+// It's rolled back if f returns an error or panics.
+//
+// f is retried like the queries of Client, so it must not have other side effects.
+//
+// InTx applies Idempotent and WithExecuteOptions, the execute options are the defaults
+// of every statement. Other options return ErrUnsupportedOption, e.g. TxControl and
+// AutoTxControl, the transaction is set by txSettings, or CommitTx, pass it to a statement.
+// Ex:
+//
+//	err := yqe.InTx(ctx, db.Table(), table.WithSerializableReadWrite(), func(tx *yqe.Tx) error {
+//		var user User
+//		if err := tx.GetX(ctx, &user, yqb.Select("*").From("users").Where(yqb.Eq{"id": 1})); err != nil {
+//			return err
+//		}
+//		_, err := tx.ExecX(ctx, yqb.Update("users").Set("visits", user.Visits+1).Where(yqb.Eq{"id": 1}), yqe.CommitTx())
+//		return err
+//	})
+func InTx(ctx context.Context, client table.Client, txSettings table.TxOption, f func(tx *Tx) error, opts ...Option) error {
+	if txSettings == nil {
+		txSettings = table.WithSerializableReadWrite()
+	}
+
+	o, err := applyTxOptions(opts)
+	if err != nil {
+		return err
+	}
+	if o.commit {
+		return fmt.Errorf("%w: CommitTx of InTx, pass it to the last statement", ErrUnsupportedOption)
+	}
+
+	var doOpts []table.Option
+	if o.idempotent {
+		doOpts = append(doOpts, table.WithIdempotent())
+	}
+
+	err = client.Do(ctx, func(ctx context.Context, s table.Session) error {
+		tx := &Tx{
+			session:  s,
+			settings: txSettings,
+			execOpts: o.execOpts,
+		}
+		return tx.run(ctx, f)
+	}, doOpts...)
+	if err != nil {
+		return fmt.Errorf("client.Do: %w", err)
+	}
+	return nil
+}
+
+// applyTxOptions applies the options of InTx or Tx statements, it returns ErrUnsupportedOption
+// for the options of the transaction control and Paginate
+func applyTxOptions(opts []Option) (callOptions, error) {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	switch {
+	case o.txc != nil:
+		return o, fmt.Errorf("%w: TxControl in a transaction", ErrUnsupportedOption)
+	case o.autoTxc:
+		return o, fmt.Errorf("%w: AutoTxControl in a transaction", ErrUnsupportedOption)
+	case o.pagination != nil:
+		return o, fmt.Errorf("%w: Paginate in a transaction", ErrUnsupportedOption)
+	}
+	return o, nil
+}
+
+// run runs f, then commits or rolls back the transaction
+func (t *Tx) run(ctx context.Context, f func(tx *Tx) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			_ = t.rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err = f(t); err != nil {
+		if rollbackErr := t.rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("%w: %w", err, rollbackErr)
+		}
+		return err
+	}
+
+	return t.commit(ctx)
+}
+
+func (t *Tx) commit(ctx context.Context) error {
+	if t.tx == nil || t.committed {
+		return nil
+	}
+	if _, err := t.tx.CommitTx(ctx); err != nil {
+		return fmt.Errorf("tx.CommitTx: %w", err)
+	}
+	t.committed = true
+	return nil
+}
+
+func (t *Tx) rollback(ctx context.Context) error {
+	if t.tx == nil || t.committed {
+		return nil
+	}
+	if err := t.tx.Rollback(ctx); err != nil {
+		return fmt.Errorf("tx.Rollback: %w", err)
+	}
+	return nil
+}
+
+// execute runs f with the Executor of the session, the transaction control of it
+// begins the transaction or continues it
+//
+// The statement applies CommitTx and WithExecuteOptions, other options return ErrUnsupportedOption.
+func (t *Tx) execute(opts []Option, f func(e *Executor) (table.Transaction, error)) error {
+	if t.committed {
+		return ErrTxDone
+	}
+	o, err := applyTxOptions(opts)
+	if err != nil {
+		return err
+	}
+	if o.idempotent {
+		return fmt.Errorf("%w: Idempotent of a statement, pass it to InTx", ErrUnsupportedOption)
+	}
+
+	txcOpts := []table.TxControlOption{table.BeginTx(t.settings)}
+	if t.tx != nil {
+		txcOpts = []table.TxControlOption{table.WithTx(t.tx)}
+	}
+	if o.commit {
+		txcOpts = append(txcOpts, table.CommitTx())
+	}
+
+	e := UseSession(t.session).WithTxControl(table.TxControl(txcOpts...)).WithExecuteOptions(t.execOpts...)
+	tx, err := f(e.WithExecuteOptions(o.execOpts...))
+	if err != nil {
+		return err
+	}
+
+	if t.tx == nil {
+		t.tx = tx
+	}
+	t.committed = o.commit
+	return nil
+}
+
+// Exec accepting SQL string and parameters
+func (t *Tx) Exec(ctx context.Context, query string, params []table.ParameterOption, opts ...Option) (result.Result, error) {
+	var res result.Result
+	err := t.execute(opts, func(e *Executor) (tx table.Transaction, err error) {
+		tx, res, err = e.Exec(ctx, query, params)
+		return tx, err
+	})
+	return res, err
+}
+
+// ExecX exec accepting SQL builder
+func (t *Tx) ExecX(ctx context.Context, query yqb.YdbSqlizer, opts ...Option) (result.Result, error) {
+	ydbSqlStr, ydbParams, err := query.ToYdbSql()
+	if err != nil {
+		return nil, fmt.Errorf("query.ToYdbSql: %w", err)
+	}
+
+	return t.Exec(ctx, ydbSqlStr, ydbParams, opts...)
+}
+
+// Get accepting SQL string and parameters
+func (t *Tx) Get(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...Option) error {
+	return t.execute(opts, func(e *Executor) (table.Transaction, error) {
+		return e.Get(ctx, dst, query, params)
+	})
+}
+
+// GetX get accepting SQL builder
+func (t *Tx) GetX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...Option) error {
+	ydbSqlStr, ydbParams, err := query.ToYdbSql()
+	if err != nil {
+		return fmt.Errorf("query.ToYdbSql: %w", err)
+	}
+
+	return t.Get(ctx, dst, ydbSqlStr, ydbParams, opts...)
+}
+
+// Select accepting SQL string and parameters
+func (t *Tx) Select(ctx context.Context, dst any, query string, params []table.ParameterOption, opts ...Option) error {
+	return t.execute(opts, func(e *Executor) (table.Transaction, error) {
+		return e.Select(ctx, dst, query, params)
+	})
+}
+
+// SelectX select accepting SQL builder
+func (t *Tx) SelectX(ctx context.Context, dst any, query yqb.YdbSqlizer, opts ...Option) error {
+	ydbSqlStr, ydbParams, err := query.ToYdbSql()
+	if err != nil {
+		return fmt.Errorf("query.ToYdbSql: %w", err)
+	}
+
+	return t.Select(ctx, dst, ydbSqlStr, ydbParams, opts...)
+}
+
+// SelectMulti accepting SQL string and parameters, see Executor.SelectMulti
+func (t *Tx) SelectMulti(ctx context.Context, query string, params []table.ParameterOption, dsts []any, opts ...Option) error {
+	return t.execute(opts, func(e *Executor) (table.Transaction, error) {
+		return e.SelectMulti(ctx, query, params, dsts)
+	})
+}
+
+// SelectMultiX select multi accepting SQL builder, e.g. yqb.Multi
+func (t *Tx) SelectMultiX(ctx context.Context, query yqb.YdbSqlizer, dsts []any, opts ...Option) error {
+	ydbSqlStr, ydbParams, err := query.ToYdbSql()
+	if err != nil {
+		return fmt.Errorf("query.ToYdbSql: %w", err)
+	}
+
+	return t.SelectMulti(ctx, ydbSqlStr, ydbParams, dsts, opts...)
+}
@@ -0,0 +1,126 @@
+package yqe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/stats"
+)
+
+func TestInTx(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+
+	var txcs []*table.TransactionControl
+	err := InTx(ctx, stub, nil, func(tx *Tx) error {
+		if _, err := tx.ExecX(ctx, yqb.Delete("a").Where(yqb.Eq{"id": 1})); err != nil {
+			return err
+		}
+		txcs = append(txcs, stub.session.txc)
+
+		if _, err := tx.ExecX(ctx, yqb.Delete("b").Where(yqb.Eq{"id": 1})); err != nil {
+			return err
+		}
+		txcs = append(txcs, stub.session.txc)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, stub.options.Idempotent)
+
+	expectedTxcs := []*table.TransactionControl{
+		table.TxControl(table.BeginTx(table.WithSerializableReadWrite())),
+		table.TxControl(table.WithTx(stub.session.tx)),
+	}
+	assert.Equal(t, expectedTxcs, txcs)
+	assert.True(t, stub.session.tx.committed)
+	assert.False(t, stub.session.tx.rolledBack)
+}
+
+func TestInTxCommitInline(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+
+	err := InTx(ctx, stub, table.WithOnlineReadOnly(), func(tx *Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT 1", nil, CommitTx()); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, "SELECT 2", nil)
+		assert.ErrorIs(t, err, ErrTxDone)
+		return nil
+	}, Idempotent())
+	assert.NoError(t, err)
+	assert.True(t, stub.options.Idempotent)
+
+	expectedTxc := table.TxControl(table.BeginTx(table.WithOnlineReadOnly()), table.CommitTx())
+	assert.Equal(t, expectedTxc, stub.session.txc)
+	assert.False(t, stub.session.tx.committed)
+}
+
+func TestInTxRollback(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+	errFailed := errors.New("failed")
+
+	err := InTx(ctx, stub, nil, func(tx *Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM a", nil); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+	assert.True(t, stub.session.tx.rolledBack)
+	assert.False(t, stub.session.tx.committed)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = InTx(ctx, stub, nil, func(tx *Tx) error {
+			_, _ = tx.Exec(ctx, "DELETE FROM a", nil)
+			panic("boom")
+		})
+	})
+	assert.True(t, stub.session.tx.rolledBack)
+
+	err = InTx(ctx, stub, nil, func(tx *Tx) error {
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+	assert.Nil(t, stub.session.tx)
+}
+
+func TestInTxOptions(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+
+	err := InTx(ctx, stub, nil, func(tx *Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM a", nil); err != nil {
+			return err
+		}
+		assert.Equal(t, "STATS_COLLECTION_BASIC", collectStatsMode(stub.session.opts))
+
+		_, err := tx.Exec(ctx, "DELETE FROM a", nil, WithExecuteOptions(WithDataQueryOptions(options.WithCollectStatsModeNone())))
+		assert.Equal(t, "STATS_COLLECTION_NONE", collectStatsMode(stub.session.opts))
+		return err
+	}, WithExecuteOptions(CollectStats(new(stats.QueryStats))))
+	assert.NoError(t, err)
+
+	for _, opt := range []Option{TxControl(SerializableRW()), AutoTxControl(), CommitTx(), Paginate(10, "id")} {
+		stub = &clientStub{}
+		err = InTx(ctx, stub, nil, func(tx *Tx) error { return nil }, opt)
+		assert.ErrorIs(t, err, ErrUnsupportedOption)
+		assert.Nil(t, stub.session)
+	}
+
+	for _, opt := range []Option{TxControl(SerializableRW()), AutoTxControl(), Idempotent(), Paginate(10, "id")} {
+		stub = &clientStub{}
+		err = InTx(ctx, stub, nil, func(tx *Tx) error {
+			_, err := tx.Exec(ctx, "DELETE FROM a", nil, opt)
+			return err
+		})
+		assert.ErrorIs(t, err, ErrUnsupportedOption)
+		assert.Empty(t, stub.session.query)
+	}
+}
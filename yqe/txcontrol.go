@@ -0,0 +1,45 @@
+package yqe
+
+import (
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+// SerializableRW returns table.TransactionControl of a serializable read-write transaction
+// committed with the query, it's the same as table.DefaultTxControl
+func SerializableRW() *table.TransactionControl {
+	return table.TxControl(table.BeginTx(table.WithSerializableReadWrite()), table.CommitTx())
+}
+
+// OnlineRO returns table.TransactionControl of an online read-only transaction
+// with consistent reads committed with the query
+func OnlineRO() *table.TransactionControl {
+	return table.TxControl(table.BeginTx(table.WithOnlineReadOnly()), table.CommitTx())
+}
+
+// OnlineROInconsistent returns table.TransactionControl of an online read-only transaction
+// with inconsistent reads committed with the query
+func OnlineROInconsistent() *table.TransactionControl {
+	return table.TxControl(table.BeginTx(table.WithOnlineReadOnly(table.WithInconsistentReads())), table.CommitTx())
+}
+
+// StaleRO returns table.TransactionControl of a stale read-only transaction
+// committed with the query
+func StaleRO() *table.TransactionControl {
+	return table.TxControl(table.BeginTx(table.WithStaleReadOnly()), table.CommitTx())
+}
+
+// SnapshotRO returns table.TransactionControl of a snapshot read-only transaction
+// committed with the query
+func SnapshotRO() *table.TransactionControl {
+	return table.TxControl(table.BeginTx(table.WithSnapshotReadOnly()), table.CommitTx())
+}
+
+// txControlOf returns OnlineRO for read-only statements and SerializableRW for others,
+// including unknown and mixed read and write statements
+func txControlOf(kind yqb.StatementKind) *table.TransactionControl {
+	if kind == yqb.StatementRead {
+		return OnlineRO()
+	}
+	return SerializableRW()
+}
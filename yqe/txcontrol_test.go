@@ -0,0 +1,70 @@
+package yqe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flymedllva/ydb-go-qb/yqb"
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+func TestTxControlPresets(t *testing.T) {
+	assert.Equal(t, table.DefaultTxControl(), SerializableRW())
+	assert.Equal(t, table.TxControl(table.BeginTx(table.WithSnapshotReadOnly()), table.CommitTx()), SnapshotRO())
+	assert.True(t, OnlineROInconsistent().Desc().GetBeginTx().GetOnlineReadOnly().GetAllowInconsistentReads())
+	assert.False(t, OnlineRO().Desc().GetBeginTx().GetOnlineReadOnly().GetAllowInconsistentReads())
+	assert.NotNil(t, StaleRO().Desc().GetBeginTx().GetStaleReadOnly())
+}
+
+func TestClientAutoTxControl(t *testing.T) {
+	ctx := context.Background()
+	stub := &clientStub{}
+	c := New(stub, AutoTxControl())
+
+	_, err := c.ExecX(ctx, yqb.Select("id").From("users"))
+	assert.NoError(t, err)
+	assert.Equal(t, OnlineRO(), stub.session.txc)
+
+	_, err = c.ExecX(ctx, yqb.Update("users").Set("a", 1).Where(yqb.Eq{"id": 1}).Returning("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, SerializableRW(), stub.session.txc)
+
+	_, err = c.Exec(ctx, "DECLARE $p1 AS Int64;\nSELECT $p1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, OnlineRO(), stub.session.txc)
+
+	_, err = c.Exec(ctx, "-- list users\nSELECT * FROM users WHERE name = ';DELETE'", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, OnlineRO(), stub.session.txc)
+
+	_, err = c.Exec(ctx, "SELECT * FROM users; UPSERT INTO users (id) VALUES (1)", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, SerializableRW(), stub.session.txc)
+
+	_, err = c.Exec(ctx, "$ids = SELECT id FROM users; SELECT * FROM $ids", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, SerializableRW(), stub.session.txc)
+
+	_, err = c.ExecX(ctx, yqb.Select("id").From("users"), TxControl(SnapshotRO()))
+	assert.NoError(t, err)
+	assert.Equal(t, SnapshotRO(), stub.session.txc)
+}
+
+func TestExecutorAutoTxControl(t *testing.T) {
+	ctx := context.Background()
+	s := &sessionStub{}
+	e := UseSession(s).WithAutoTxControl()
+
+	_, _, err := e.ExecX(ctx, yqb.Select("id").From("users"))
+	assert.NoError(t, err)
+	assert.Equal(t, OnlineRO(), s.txc)
+
+	_, _, err = e.ExecX(ctx, yqb.Delete("users").Where(yqb.Eq{"id": 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, SerializableRW(), s.txc)
+
+	_, _, err = e.Exec(ctx, "SELECT * FROM users; DELETE FROM users WHERE id = 1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, SerializableRW(), s.txc)
+}
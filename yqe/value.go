@@ -0,0 +1,395 @@
+package yqe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// structColumn is a field of a struct scanned from a column by yscan
+type structColumn struct {
+	name  string
+	index []int
+	// typ is the YDB type of the ydb tag, nil if the field has no tag
+	typ types.Type
+}
+
+// structColumns returns the columns of the struct type by the db tag or the snake case field name,
+// the fields of embedded structs and struct pointers without the tag are columns of the struct too
+func structColumns(t reflect.Type) ([]structColumn, error) {
+	var columns []structColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, hasTag := field.Tag.Lookup("db")
+		name, _, _ := strings.Cut(tag, ",")
+		if embeddedType, ok := embeddedStructType(field); ok && !hasTag {
+			embedded, err := structColumns(embeddedType)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range embedded {
+				c.index = append([]int{i}, c.index...)
+				columns = append(columns, c)
+			}
+			continue
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = dbscan.SnakeCaseMapper(field.Name)
+		}
+
+		column := structColumn{name: name, index: []int{i}}
+		if yql, ok := field.Tag.Lookup("ydb"); ok {
+			typ, err := parseType(yql)
+			if err != nil {
+				return nil, fmt.Errorf("ydb tag of field %s: %w", field.Name, err)
+			}
+			if field.Type.Kind() == reflect.Ptr && !isOptional(typ) {
+				typ = types.Optional(typ)
+			}
+			column.typ = typ
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// embeddedStructType returns the struct type of the embedded struct or struct pointer field
+func embeddedStructType(field reflect.StructField) (reflect.Type, bool) {
+	if !field.Anonymous {
+		return nil, false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// field returns the field of the column in the struct v, it returns false
+// if an embedded struct pointer on the way to the field is nil
+func (c structColumn) field(v reflect.Value) (reflect.Value, bool) {
+	field, err := v.FieldByIndexErr(c.index)
+	return field, err == nil
+}
+
+// columnTypes returns the types of the columns of the table description
+func columnTypes(desc *options.Description) map[string]types.Type {
+	if desc == nil {
+		return nil
+	}
+	typs := make(map[string]types.Type, len(desc.Columns))
+	for _, c := range desc.Columns {
+		typs[c.Name] = c.Type
+	}
+	return typs
+}
+
+// primitiveTypes are the types of ydb tags by the YQL names
+var primitiveTypes = func() map[string]types.Type {
+	typs := make(map[string]types.Type)
+	for _, t := range []types.Type{
+		types.TypeBool,
+		types.TypeInt8, types.TypeInt16, types.TypeInt32, types.TypeInt64,
+		types.TypeUint8, types.TypeUint16, types.TypeUint32, types.TypeUint64,
+		types.TypeFloat, types.TypeDouble,
+		types.TypeDate, types.TypeDatetime, types.TypeTimestamp, types.TypeInterval,
+		types.TypeBytes, types.TypeText, types.TypeYSON, types.TypeJSON, types.TypeJSONDocument,
+		types.TypeUUID, types.TypeDyNumber,
+	} {
+		typs[t.Yql()] = t
+	}
+	return typs
+}()
+
+// parseType parses the YQL type of a ydb tag, e.g. Uint64 or Optional<Date>
+func parseType(yql string) (types.Type, error) {
+	yql = strings.TrimSpace(yql)
+	if inner, ok := strings.CutPrefix(yql, "Optional<"); ok && strings.HasSuffix(inner, ">") {
+		t, err := parseType(strings.TrimSuffix(inner, ">"))
+		if err != nil {
+			return nil, err
+		}
+		return types.Optional(t), nil
+	}
+	if t, ok := primitiveTypes[yql]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported type `%s`", yql)
+}
+
+func isOptional(t types.Type) bool {
+	return strings.HasPrefix(t.Yql(), "Optional<")
+}
+
+// optionalItem returns the item type of the Optional type
+func optionalItem(t types.Type) (types.Type, error) {
+	yql := t.Yql()
+	return parseType(yql[len("Optional<") : len(yql)-1])
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	valueType    = reflect.TypeOf((*types.Value)(nil)).Elem()
+)
+
+// typeOf returns the YDB type of values of the Go type, it's used for fields without a type
+// of the ydb tag or the table description
+func typeOf(t reflect.Type) (types.Type, error) {
+	switch t {
+	case timeType:
+		return types.TypeTimestamp, nil
+	case durationType:
+		return types.TypeInterval, nil
+	case reflect.TypeOf(json.RawMessage(nil)):
+		return types.TypeJSON, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		inner, err := typeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return types.Optional(inner), nil
+	case reflect.Bool:
+		return types.TypeBool, nil
+	case reflect.Int, reflect.Int64:
+		return types.TypeInt64, nil
+	case reflect.Int8:
+		return types.TypeInt8, nil
+	case reflect.Int16:
+		return types.TypeInt16, nil
+	case reflect.Int32:
+		return types.TypeInt32, nil
+	case reflect.Uint, reflect.Uint64:
+		return types.TypeUint64, nil
+	case reflect.Uint8:
+		return types.TypeUint8, nil
+	case reflect.Uint16:
+		return types.TypeUint16, nil
+	case reflect.Uint32:
+		return types.TypeUint32, nil
+	case reflect.Float32:
+		return types.TypeFloat, nil
+	case reflect.Float64:
+		return types.TypeDouble, nil
+	case reflect.String:
+		return types.TypeText, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return types.TypeBytes, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type `%s`", t)
+}
+
+// valueOf returns the YDB value of the type t of the Go value v, t is inferred by typeOf for nil
+func valueOf(v reflect.Value, t types.Type) (types.Value, error) {
+	if v.Type().Implements(valueType) {
+		if v.Kind() == reflect.Interface && v.IsNil() {
+			return nil, fmt.Errorf("nil value")
+		}
+		return v.Interface().(types.Value), nil
+	}
+	if t == nil {
+		var err error
+		if t, err = typeOf(v.Type()); err != nil {
+			return nil, err
+		}
+	}
+
+	if isOptional(t) {
+		item, err := optionalItem(t)
+		if err != nil {
+			return nil, err
+		}
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return types.NullValue(item), nil
+			}
+			v = v.Elem()
+		}
+		val, err := valueOf(v, item)
+		if err != nil {
+			return nil, err
+		}
+		return types.OptionalValue(val), nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("nil value of %s", t.Yql())
+		}
+		v = v.Elem()
+	}
+
+	if val, ok := primitiveValue(v, t); ok {
+		return val, nil
+	}
+	return nil, fmt.Errorf("%s value of %s", v.Type(), t.Yql())
+}
+
+// primitiveValue converts the Go value to the primitive type t, it returns false
+// for values that can't be converted
+func primitiveValue(v reflect.Value, t types.Type) (types.Value, bool) {
+	switch {
+	case types.Equal(t, types.TypeBool) && v.Kind() == reflect.Bool:
+		return types.BoolValue(v.Bool()), true
+	case v.Type() == timeType:
+		tm := v.Interface().(time.Time)
+		switch {
+		case types.Equal(t, types.TypeDate):
+			return types.DateValueFromTime(tm), true
+		case types.Equal(t, types.TypeDatetime):
+			return types.DatetimeValueFromTime(tm), true
+		case types.Equal(t, types.TypeTimestamp):
+			return types.TimestampValueFromTime(tm), true
+		}
+	case v.Type() == durationType && types.Equal(t, types.TypeInterval):
+		return types.IntervalValueFromDuration(time.Duration(v.Int())), true
+	case v.CanInt():
+		return intValue(v.Int(), t)
+	case v.CanUint():
+		if val, ok := uintValue(v.Uint(), t); ok || v.Uint() > math.MaxInt64 {
+			return val, ok
+		}
+		return intValue(int64(v.Uint()), t)
+	case v.CanFloat():
+		switch {
+		case types.Equal(t, types.TypeFloat):
+			return types.FloatValue(float32(v.Float())), true
+		case types.Equal(t, types.TypeDouble):
+			return types.DoubleValue(v.Float()), true
+		}
+	case v.Kind() == reflect.String || v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return textValue(v, t)
+	case v.Kind() == reflect.Array && v.Len() == 16 && v.Type().Elem().Kind() == reflect.Uint8 && types.Equal(t, types.TypeUUID):
+		var uuid [16]byte
+		reflect.Copy(reflect.ValueOf(uuid[:]), v)
+		return types.UUIDValue(uuid), true
+	}
+	return nil, false
+}
+
+func intValue(i int64, t types.Type) (types.Value, bool) {
+	switch {
+	case types.Equal(t, types.TypeInt8) && int64(int8(i)) == i:
+		return types.Int8Value(int8(i)), true
+	case types.Equal(t, types.TypeInt16) && int64(int16(i)) == i:
+		return types.Int16Value(int16(i)), true
+	case types.Equal(t, types.TypeInt32) && int64(int32(i)) == i:
+		return types.Int32Value(int32(i)), true
+	case types.Equal(t, types.TypeInt64):
+		return types.Int64Value(i), true
+	case types.Equal(t, types.TypeInterval):
+		return types.IntervalValueFromMicroseconds(i), true
+	case i >= 0:
+		return uintValue(uint64(i), t)
+	}
+	return nil, false
+}
+
+func uintValue(u uint64, t types.Type) (types.Value, bool) {
+	switch {
+	case types.Equal(t, types.TypeUint8) && uint64(uint8(u)) == u:
+		return types.Uint8Value(uint8(u)), true
+	case types.Equal(t, types.TypeUint16) && uint64(uint16(u)) == u:
+		return types.Uint16Value(uint16(u)), true
+	case types.Equal(t, types.TypeUint32) && uint64(uint32(u)) == u:
+		return types.Uint32Value(uint32(u)), true
+	case types.Equal(t, types.TypeUint64):
+		return types.Uint64Value(u), true
+	case types.Equal(t, types.TypeDate) && uint64(uint32(u)) == u:
+		return types.DateValue(uint32(u)), true
+	case types.Equal(t, types.TypeDatetime) && uint64(uint32(u)) == u:
+		return types.DatetimeValue(uint32(u)), true
+	case types.Equal(t, types.TypeTimestamp):
+		return types.TimestampValue(u), true
+	}
+	return nil, false
+}
+
+func textValue(v reflect.Value, t types.Type) (types.Value, bool) {
+	var b []byte
+	if v.Kind() == reflect.String {
+		b = []byte(v.String())
+	} else {
+		b = v.Bytes()
+	}
+	switch {
+	case types.Equal(t, types.TypeText):
+		return types.TextValue(string(b)), true
+	case types.Equal(t, types.TypeBytes):
+		return types.BytesValue(b), true
+	case types.Equal(t, types.TypeJSON):
+		return types.JSONValueFromBytes(b), true
+	case types.Equal(t, types.TypeJSONDocument):
+		return types.JSONDocumentValueFromBytes(b), true
+	case types.Equal(t, types.TypeYSON):
+		return types.YSONValueFromBytes(b), true
+	case types.Equal(t, types.TypeDyNumber):
+		return types.DyNumberValue(string(b)), true
+	}
+	return nil, false
+}
+
+// structValue returns the YDB struct value of the columns of the struct v,
+// the types of the table columns are used for the columns without a type of the ydb tag
+func structValue(v reflect.Value, columns []structColumn, tableTypes map[string]types.Type) (types.Value, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("nil row")
+		}
+		v = v.Elem()
+	}
+
+	fields := make([]types.StructValueOption, 0, len(columns))
+	for _, c := range columns {
+		val, err := c.value(v, tableTypes)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, types.StructFieldValue(c.name, val))
+	}
+	return types.StructValue(fields...), nil
+}
+
+// value returns the YDB value of the column of the struct v, the type of the ydb tag
+// or of the table column is used if it's known
+func (c structColumn) value(v reflect.Value, tableTypes map[string]types.Type) (types.Value, error) {
+	typ := c.typ
+	if typ == nil {
+		typ = tableTypes[c.name]
+	}
+	field, ok := c.field(v)
+	if !ok {
+		// the columns of a nil embedded struct pointer are NULL, the type is
+		// the type of the field, so it's the same as with the struct set
+		t := v.Type().FieldByIndex(c.index).Type
+		if typ == nil {
+			var err error
+			if typ, err = typeOf(t); err != nil {
+				return nil, fmt.Errorf("column %q: %w", c.name, err)
+			}
+		}
+		field = reflect.Zero(reflect.PtrTo(t))
+	}
+	val, err := valueOf(field, typ)
+	if err != nil {
+		return nil, fmt.Errorf("column %q: %w", c.name, err)
+	}
+	return val, nil
+}
@@ -5,4 +5,8 @@ import "errors"
 var (
 	// ErrNoRows occurs when rows are expected but none are returned.
 	ErrNoRows = errors.New("no rows in result set")
+	// ErrResultSetCount occurs when the number of result sets differs from the number of destinations.
+	ErrResultSetCount = errors.New("number of result sets differs from number of destinations")
+	// ErrTruncated occurs when the result set is truncated by the server, e.g. by the limit of 1000 rows.
+	ErrTruncated = errors.New("result set is truncated")
 )
@@ -14,3 +14,13 @@ func isDoublePointer(i interface{}) bool {
 
 	return true
 }
+
+// isSlicePointer checks if the given interface is a pointer to a slice, except []byte
+func isSlicePointer(i interface{}) bool {
+	t := reflect.TypeOf(i)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return false
+	}
+	t = t.Elem()
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
@@ -0,0 +1,80 @@
+package yscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// SelectMulti is a high-level function that queries several result sets from Querier and calls the ScanMulti function.
+// See ScanMulti for details.
+func (a *API) SelectMulti(ctx context.Context, tx *table.TransactionControl, db SessionQuerier, query string, params *table.QueryParameters, dsts ...any) (table.Transaction, error) {
+	trx, r, err := db.Execute(ctx, tx, query, params)
+	if err != nil {
+		return trx, fmt.Errorf("scany: query multiple result sets: %w", err)
+	}
+	defer r.Close()
+
+	if err = a.ScanMulti(ctx, r, dsts...); err != nil {
+		return trx, fmt.Errorf("scanning multi: %w", err)
+	}
+
+	return trx, nil
+}
+
+// SelectMultiTx is a high-level function that queries several result sets from Querier and calls the ScanMulti function.
+// See ScanMulti for details.
+func (a *API) SelectMultiTx(ctx context.Context, db TransactionQuerier, query string, params *table.QueryParameters, dsts ...any) error {
+	r, err := db.Execute(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("scany: query multiple result sets: %w", err)
+	}
+	defer r.Close()
+
+	if err = a.ScanMulti(ctx, r, dsts...); err != nil {
+		return fmt.Errorf("scanning multi: %w", err)
+	}
+
+	return nil
+}
+
+// ScanMulti scans the result sets of the result into the destinations in order.
+// A pointer to a slice is scanned like ScanAll, any other destination like ScanOne.
+// It returns ErrResultSetCount if the number of result sets differs from the number of destinations.
+func (a *API) ScanMulti(ctx context.Context, r result.Result, dsts ...any) error {
+	if n := r.ResultSetCount(); n != len(dsts) {
+		return fmt.Errorf("%w: %d result sets, %d destinations", ErrResultSetCount, n, len(dsts))
+	}
+
+	for i, dst := range dsts {
+		if err := r.NextResultSetErr(ctx); err != nil {
+			return fmt.Errorf("result.NextResultSetErr: %w", err)
+		}
+
+		rows := resultSetAdapter{RowsAdapter{result: r}}
+		var err error
+		if isSlicePointer(dst) {
+			err = a.scanAll(dst, r, rows)
+		} else {
+			err = a.scanOne(dst, rows)
+		}
+		if err != nil {
+			return fmt.Errorf("result set %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// resultSetAdapter is a RowsAdapter of the current result set,
+// Close leaves the result open to scan the next result sets.
+type resultSetAdapter struct {
+	RowsAdapter
+}
+
+// Close implements the dbscan.Rows.Close method.
+func (ra resultSetAdapter) Close() error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package yscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// ScanEach scans the rows of all result sets of the result one by one into dst
+// and calls f after each row, so the rows aren't held in memory.
+// It stops and returns the error of f if f returns an error.
+func (a *API) ScanEach(ctx context.Context, r result.BaseResult, dst any, f func() error) error {
+	for {
+		err := r.NextResultSetErr(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("result.NextResultSetErr: %w", err)
+		}
+
+		rs := a.NewRowScanner(r)
+		for r.NextRow() {
+			if err = rs.Scan(dst); err != nil {
+				return fmt.Errorf("scanning row: %w", err)
+			}
+			if err = f(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.Err(); err != nil {
+		return fmt.Errorf("result.Err: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package yscan
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/georgysavva/scany/v2/dbscan"
@@ -40,6 +41,46 @@ var (
 	_ TransactionQuerier = table.TransactionActor(nil)
 )
 
+// StreamQuerier is an interface that is implemented by table.Session.
+type StreamQuerier interface {
+	StreamExecuteScanQuery(
+		ctx context.Context,
+		query string,
+		params *table.QueryParameters,
+		opts ...options.ExecuteScanQueryOption,
+	) (result.StreamResult, error)
+}
+
+var (
+	_ StreamQuerier = table.Session(nil)
+)
+
+// TableReader is an interface that is implemented by table.Session.
+type TableReader interface {
+	StreamReadTable(
+		ctx context.Context,
+		path string,
+		opts ...options.ReadTableOption,
+	) (result.StreamResult, error)
+}
+
+var (
+	_ TableReader = table.Session(nil)
+)
+
+// SchemeQuerier is an interface that is implemented by table.Session.
+type SchemeQuerier interface {
+	ExecuteSchemeQuery(
+		ctx context.Context,
+		query string,
+		opts ...options.ExecuteSchemeQueryOption,
+	) error
+}
+
+var (
+	_ SchemeQuerier = table.Session(nil)
+)
+
 // RowScanner is a wrapper around the dbscan.RowScanner type.
 // See dbscan.RowScanner for details.
 type RowScanner struct {
@@ -69,14 +110,30 @@ func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
 // API is a wrapper around the dbscan.API type.
 // See dbscan.API for details.
 type API struct {
-	dbscanAPI *dbscan.API
+	dbscanAPI      *dbscan.API
+	allowTruncated bool
+}
+
+// APIOption is a function that can be used to configure an API.
+type APIOption func(api *API)
+
+// WithAllowTruncated allows ScanAll to scan result sets truncated by the server without ErrTruncated.
+// The SDK must be configured to ignore truncated results too, see ydb.WithIgnoreTruncated.
+func WithAllowTruncated() APIOption {
+	return func(api *API) {
+		api.allowTruncated = true
+	}
 }
 
 // NewAPI creates new API instance from dbscan.API instance.
-func NewAPI(dbscanAPI *dbscan.API) (*API, error) {
-	return &API{
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{
 		dbscanAPI: dbscanAPI,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(api)
+	}
+	return api, nil
 }
 
 // Select is a high-level function that queries rows from Querier and calls the ScanAll function.
@@ -176,16 +233,35 @@ func (a *API) GetTx(ctx context.Context, db TransactionQuerier, dst any, query s
 }
 
 // ScanAll is a wrapper around the dbscan.ScanAll function.
-// See dbscan.ScanAll for details.
-func (a *API) ScanAll(dst any, result result.BaseResult) error {
-	return a.dbscanAPI.ScanAll(dst, NewRowsAdapter(result))
+// See dbscan.ScanAll for details. If the result set is truncated
+// by the server it returns an ErrTruncated error, see WithAllowTruncated.
+func (a *API) ScanAll(dst any, r result.BaseResult) error {
+	return a.scanAll(dst, r, NewRowsAdapter(r))
+}
+
+func (a *API) scanAll(dst any, r result.BaseResult, rows dbscan.Rows) error {
+	err := a.dbscanAPI.ScanAll(dst, rows)
+	switch {
+	case errors.Is(err, result.ErrTruncated):
+		return fmt.Errorf("%w: %v", ErrTruncated, err)
+	case err != nil:
+		return err
+	case !a.allowTruncated && r.CurrentResultSet().Truncated():
+		return fmt.Errorf("%w: %d rows read", ErrTruncated, r.CurrentResultSet().RowCount())
+	default:
+		return nil
+	}
 }
 
 // ScanOne is a wrapper around the dbscan.ScanOne function.
 // See dbscan.ScanOne for details. If no rows are found it
 // returns a pgx.ErrNoRows error.
 func (a *API) ScanOne(dst any, result result.BaseResult) error {
-	switch err := a.dbscanAPI.ScanOne(dst, NewRowsAdapter(result)); {
+	return a.scanOne(dst, NewRowsAdapter(result))
+}
+
+func (a *API) scanOne(dst any, rows dbscan.Rows) error {
+	switch err := a.dbscanAPI.ScanOne(dst, rows); {
 	case dbscan.NotFound(err):
 		return fmt.Errorf("%w: %v", ErrNoRows, err)
 	case err != nil:
@@ -112,6 +112,41 @@ func TestGet_queryError_propagatesAndWrapsErr(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSelectMulti(t *testing.T) {
+	t.Parallel()
+	expectedMany := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	expectedOne := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var (
+		gotMany []*testModel
+		gotOne  testModel
+	)
+	err := testDB.Table().Do(ctx, func(ctx context.Context, tsc table.Session) error {
+		_, err := testAPI.SelectMulti(ctx, txc, tsc, multipleRowsQuery+";"+singleRowsQuery, nil, &gotMany, &gotOne)
+		require.NoError(t, err)
+		assert.Equal(t, expectedMany, gotMany)
+		assert.Equal(t, expectedOne, gotOne)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSelectMulti_resultSetCount(t *testing.T) {
+	t.Parallel()
+
+	var got []*testModel
+	err := testDB.Table().Do(ctx, func(ctx context.Context, tsc table.Session) error {
+		_, err := testAPI.SelectMulti(ctx, txc, tsc, multipleRowsQuery, nil, &got, &got)
+		assert.ErrorIs(t, err, yscan.ErrResultSetCount)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 func getAPI() (*yscan.API, error) {
 	dbscanAPI, err := yscan.NewDBScanAPI()
 	if err != nil {